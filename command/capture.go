@@ -0,0 +1,392 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+	"github.com/kubecolor/kubecolor/kubectl"
+	"github.com/kubecolor/kubecolor/printer"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	captureHeaderSeparator = strings.Repeat("=", 80)
+	captureOutputSeparator = strings.Repeat("-", 80)
+)
+
+// captureRedacted is used in place of secret values in the capture file.
+const captureRedacted = "<redacted>"
+
+// Capture records the input and output of a kubecolor run, and writes it as
+// a test file in the same format as used in ./test/corpus.
+// Used with the --kubecolor-capture flag.
+//
+// The expected output is created by running the redacted input through
+// the printers again, so the test stays in sync with its input.
+type Capture struct {
+	Args       []string
+	Config     *config.Config
+	Version    string
+	ColorLevel string
+	Printers   *Printers
+
+	RawStdout bytes.Buffer
+	RawStderr bytes.Buffer
+}
+
+// captureEnvPrefixes are the environment variables to include in the capture.
+var captureEnvPrefixes = []string{"KUBECOLOR_", "TERM=", "COLORTERM="}
+
+// WriteFile writes the capture to a file at the given path.
+func (c *Capture) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := c.Write(&buf, os.Environ()); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// Write writes the capture in the test corpus format.
+// It writes one test for stdout, and another for stderr when it's not empty.
+func (c *Capture) Write(w io.Writer, environ []string) error {
+	envMap := map[string]string{}
+	for _, e := range environ {
+		if slices.ContainsFunc(captureEnvPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(e, prefix)
+		}) {
+			key, value, _ := strings.Cut(e, "=")
+			envMap[key] = value
+		}
+	}
+	// The config file is replaced by the env vars from [Capture.configEnv]
+	delete(envMap, "KUBECOLOR_CONFIG")
+
+	args := redactArgs(c.Args)
+	binary := "kubectl"
+	if c.Config != nil {
+		configEnv, err := c.configEnv()
+		if err != nil {
+			return fmt.Errorf("capture config: %w", err)
+		}
+		maps.Copy(envMap, configEnv)
+		if c.Config.DecodeSecrets {
+			// Can only be set with a flag
+			args = append(args, "--kubecolor-decode-secrets")
+		}
+		binary = kubectl.DialectFromCommand(c.Config.Kubectl).String()
+	}
+	var env []string
+	for key, value := range envMap {
+		env = append(env, key+"="+value)
+	}
+	slices.Sort(env)
+
+	comments := []string{
+		fmt.Sprintf("kubecolor version: %s", c.Version),
+		fmt.Sprintf("color level: %s", c.ColorLevel),
+		fmt.Sprintf("printer: %s", capturePrinterType(c.Printers.FullColoredPrinter)),
+	}
+
+	command := binary + " " + strings.Join(args, " ")
+	c.writeTest(w, "captured stdout", comments, env, command, c.RawStdout.String(), c.Printers.FullColoredPrinter)
+
+	if c.RawStderr.Len() > 0 {
+		fmt.Fprintln(w)
+		stderrEnv := append(slices.Clone(env), "INPUT_IS_STDERR=true")
+		slices.Sort(stderrEnv)
+		c.writeTest(w, "captured stderr", nil, stderrEnv, command, c.RawStderr.String(), c.Printers.ErrorPrinter)
+	}
+	return nil
+}
+
+// captureSkipConfigKeys are the config keys that aren't written as env vars,
+// as they don't change the output, or are set in another way.
+var captureSkipConfigKeys = []string{
+	"debug",
+	"decodesecrets", // set with the --kubecolor-decode-secrets flag
+	"kubectl",       // set by the command name, e.g "oc"
+	"pager",
+	"paging",
+}
+
+// configEnv returns the settings that differ from the defaults as
+// "KUBECOLOR_*" env vars, so the test is printed with the same config
+// when it's added to the test corpus, even if the settings came from
+// the config file or from flags.
+//
+// Theme colors are only included when they differ from the preset, as
+// including the whole theme would drown out the other settings.
+func (c *Capture) configEnv() (map[string]string, error) {
+	defaults, err := config.Unmarshal(config.NewDefaultViper())
+	if err != nil {
+		return nil, err
+	}
+	presetViper := config.NewDefaultViper()
+	// mapstructure doesn't like "type X string" values, so we have to convert it via string(...)
+	presetViper.Set(config.PresetKey, string(c.Config.Preset))
+	presetCfg, err := config.Unmarshal(presetViper)
+	if err != nil {
+		return nil, err
+	}
+	defaults.Theme = presetCfg.Theme
+
+	current, err := flattenSettings(c.Config)
+	if err != nil {
+		return nil, err
+	}
+	base, err := flattenSettings(defaults)
+	if err != nil {
+		return nil, err
+	}
+	env := map[string]string{}
+	for key, value := range current {
+		if slices.Contains(captureSkipConfigKeys, key) || normalizeSetting(value) == normalizeSetting(base[key]) {
+			continue
+		}
+		env[captureEnvName(key)] = value
+	}
+	return env, nil
+}
+
+// normalizeSetting returns the value to compare with the defaults,
+// as colors are rendered as "none" after [config.Theme.ComputeCache].
+func normalizeSetting(value string) string {
+	if value == "none" {
+		return ""
+	}
+	return value
+}
+
+// captureEnvName returns the env var that sets the config key,
+// e.g "KUBECOLOR_THEME_TABLE_HEADER" for "theme.table.header".
+func captureEnvName(key string) string {
+	return "KUBECOLOR_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func (c *Capture) writeTest(w io.Writer, name string, comments, env []string, command, rawInput string, p printer.Printer) {
+	input := strings.TrimSpace(RedactCapture(rawInput))
+
+	fmt.Fprintln(w, captureHeaderSeparator)
+	fmt.Fprintf(w, "# %s\n", name)
+	for _, comment := range comments {
+		fmt.Fprintf(w, "# %s\n", comment)
+	}
+	for _, e := range env {
+		key, value, _ := strings.Cut(e, "=")
+		fmt.Fprintf(w, "%s=%q\n", key, value)
+	}
+	fmt.Fprintf(w, "$ %s\n", command)
+	fmt.Fprintln(w, captureHeaderSeparator)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, input)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, captureOutputSeparator)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, strings.TrimSpace(capturePrint(p, input)))
+}
+
+func capturePrint(p printer.Printer, input string) (output string) {
+	var buf bytes.Buffer
+	defer func() {
+		if r := recover(); r != nil {
+			output = fmt.Sprintf("%s\n(printer panicked: %v)", buf.String(), r)
+		}
+	}()
+	p.Print(strings.NewReader(input), &buf)
+	return buf.String()
+}
+
+// readCaptureInput returns the input of the first test in a capture file.
+func readCaptureInput(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	var (
+		separators int
+		lines      []string
+	)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case line == captureHeaderSeparator:
+			separators++
+			continue
+		case line == captureOutputSeparator && separators >= 2:
+			return strings.TrimSpace(strings.Join(lines, "\n")) + "\n", nil
+		}
+		if separators >= 2 {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no test input found in capture file")
+}
+
+// flattenSettings returns the config as a map of keys like "theme.table.header",
+// where lists are written in YAML flow style.
+func flattenSettings(v any) (map[string]string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	result := map[string]string{}
+	if err := flattenMap(result, "", m); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func flattenMap(result map[string]string, prefix string, m map[string]any) error {
+	for k, v := range m {
+		key := prefix + k
+		switch v := v.(type) {
+		case map[string]any:
+			if err := flattenMap(result, key+".", v); err != nil {
+				return err
+			}
+		case []any:
+			flow, err := flowYAML(v)
+			if err != nil {
+				return err
+			}
+			result[key] = flow
+		case nil:
+			result[key] = ""
+		default:
+			result[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// flowYAML returns the list in YAML flow style, without the empty fields,
+// e.g `[{status: error, style: bold}]`.
+func flowYAML(list []any) (string, error) {
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			maps.DeleteFunc(m, func(_ string, v any) bool {
+				return v == nil || v == ""
+			})
+		}
+	}
+	var node yaml.Node
+	if err := node.Encode(list); err != nil {
+		return "", err
+	}
+	setFlowStyle(&node)
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func setFlowStyle(node *yaml.Node) {
+	node.Style |= yaml.FlowStyle
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
+
+// captureSecretFlags are kubectl flags where the value should be redacted.
+var captureSecretFlags = []string{"--token", "--password"}
+
+func redactArgs(args []string) []string {
+	result := make([]string, len(args))
+	for i, arg := range args {
+		flag, _, hasValue := strings.Cut(arg, "=")
+		switch {
+		case hasValue && slices.Contains(captureSecretFlags, flag):
+			result[i] = flag + "=" + captureRedacted
+		case i > 0 && slices.Contains(captureSecretFlags, args[i-1]):
+			result[i] = captureRedacted
+		default:
+			result[i] = arg
+		}
+	}
+	return result
+}
+
+var captureRedactRegexes = []*regexp.Regexp{
+	// "Authorization: Bearer eyJhbGciOi..." in "kubectl -v=8" output
+	regexp.MustCompile(`(?i)(bearer\s+)[^\s"',]+`),
+	// "token: abc" or `"password": "abc"`
+	regexp.MustCompile(`(?i)((?:token|password|client-key-data|client-certificate-data)["']?\s*[:=]\s*["']?)[^\s"',]+`),
+}
+
+// RedactCapture removes tokens and the values of Secret data fields from
+// the given raw kubectl output.
+func RedactCapture(s string) string {
+	s = redactSecretData(s)
+	for _, r := range captureRedactRegexes {
+		s = r.ReplaceAllString(s, "${1}"+captureRedacted)
+	}
+	return s
+}
+
+var (
+	secretKindRegex     = regexp.MustCompile(`(?m)^\s*"?kind"?:\s*"?Secret"?,?\s*$`)
+	secretDataKeyRegex  = regexp.MustCompile(`^\s*"?(data|stringData)"?:\s*\{?\s*$`)
+	secretDataLineRegex = regexp.MustCompile(`^(\s*"?[^"]+"?:\s*"?)([^"]*)("?,?)$`)
+)
+
+// redactSecretData redacts the values in "data" and "stringData" in
+// Secrets in YAML and JSON output.
+func redactSecretData(s string) string {
+	if !secretKindRegex.MatchString(s) {
+		return s
+	}
+	var buf strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	dataIndent := -1
+	for scanner.Scan() {
+		line := scanner.Text()
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if dataIndent >= 0 && indent > dataIndent && strings.TrimSpace(line) != "" {
+			if m := secretDataLineRegex.FindStringSubmatch(line); m != nil {
+				line = m[1] + captureRedacted + m[3]
+			}
+		} else if dataIndent >= 0 && indent <= dataIndent {
+			dataIndent = -1
+		}
+
+		if dataIndent < 0 && secretDataKeyRegex.MatchString(line) {
+			dataIndent = indent
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	if !strings.HasSuffix(s, "\n") {
+		return strings.TrimSuffix(buf.String(), "\n")
+	}
+	return buf.String()
+}
+
+// capturePrinterType returns the type name of the printer that colors the output.
+func capturePrinterType(p printer.Printer) string {
+	if kp, ok := p.(*printer.KubectlOutputColoredPrinter); ok {
+		return kp.PrinterType()
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+// captureColorLevel returns the color level, for use in [Capture.ColorLevel].
+func captureColorLevel() string {
+	return color.TermColorLevel().String()
+}
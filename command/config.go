@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/internal/export"
+	"github.com/kubecolor/kubecolor/internal/stringutil"
+	"github.com/kubecolor/kubecolor/kubectl"
 	"github.com/spf13/viper"
 )
 
@@ -16,6 +19,9 @@ type Config struct {
 	ForceColor           ColorLevel
 	ShowKubecolorVersion bool
 	StdinOverride        string
+	CapturePath          string
+	Export               export.Format
+	ExportClasses        bool
 
 	ArgsPassthrough []string
 	Flags           FlagSet
@@ -72,8 +78,37 @@ func ResolveConfigViper(inputArgs []string, v *viper.Viper) (*Config, error) {
 				WithUnmarshaller(&flagPagingVal)
 
 		flagNoPaging = cfg.Flags.NewBool("--no-paging", `Disable paging. Alias to --paging=never.`)
+
+		flagCapture = cfg.Flags.NewString("--kubecolor-capture", "Write the kubectl input and kubecolor output to a file, to be attached in bug reports.").
+				WithRequiresValue()
+
+		flagExportVal = export.FormatHTML // value used when no flag value
+		flagExport    = cfg.Flags.NewString("--kubecolor-export", "Print the colored output as a standalone HTML page or SVG image, e.g --kubecolor-export=svg").
+				WithUnmarshaller(&flagExportVal)
+
+		flagExportClasses = cfg.Flags.NewBool("--kubecolor-export-classes", "Use CSS classes named after the theme keys in --kubecolor-export=html, e.g \"theme-data-string\", instead of inline styles.")
+
+		flagFoldVal = config.Fold(config.AllFoldTargets) // value used when no flag value
+		flagFold    = cfg.Flags.NewString("--kubecolor-fold", "Hide noisy parts of YAML and JSON output, e.g --kubecolor-fold=managedFields,lastApplied,blobs. Overrides the KUBECOLOR_FOLD env var.").
+				WithUnmarshaller(&flagFoldVal)
+
+		flagRedact = cfg.Flags.NewBool("--kubecolor-redact", "Mask secret values in the output, e.g when screen sharing. Overrides the KUBECOLOR_REDACT_ENABLED env var.")
+
+		flagSummary = cfg.Flags.NewBool("--kubecolor-summary", "Print a footer to stderr with counts per status or verb, e.g for \"kubectl get pods -A\". Overrides the KUBECOLOR_SUMMARY env var.")
+
+		flagIdentityColors = cfg.Flags.NewBool("--kubecolor-identity-colors", "Color namespaces, nodes, and resource kinds by their name, so they get the same color in every command. Overrides the KUBECOLOR_IDENTITY_COLORS env var.")
+
+		flagExplainTree = cfg.Flags.NewBool("--kubecolor-explain-tree", "Draw tree guides in \"kubectl explain --recursive\" output. Overrides the KUBECOLOR_EXPLAIN_TREE env var.")
+
+		flagRolloutProgress = cfg.Flags.NewBool("--kubecolor-rollout-progress", "Draw an in-place progress bar in \"kubectl rollout status\" when the output is a terminal. Overrides the KUBECOLOR_ROLLOUT_PROGRESS env var.")
+
+		flagDecodeSecrets = cfg.Flags.NewBool("--kubecolor-decode-secrets", "Show the decoded value beside each base64 encoded value in Secrets, or in place of it in JSON output. Ignored when --kubecolor-redact is enabled.")
+
+		flagOrdered = cfg.Flags.NewBool("--kubecolor-ordered-output", "Print stdout and stderr lines in the order kubectl wrote them. Overrides the KUBECOLOR_ORDERED_OUTPUT env var.")
 	)
 
+	redactFlagSet := false
+	decodeSecrets := false
 	for _, s := range inputArgs {
 		f, err := cfg.Flags.ParseArg(s)
 		if err != nil {
@@ -111,6 +146,29 @@ func ResolveConfigViper(inputArgs []string, v *viper.Viper) (*Config, error) {
 			if f.BoolValue() {
 				v.Set("paging", string(config.PagingNever))
 			}
+		case flagCapture:
+			cfg.CapturePath = f.Value
+		case flagExport:
+			cfg.Export = flagExportVal
+		case flagExportClasses:
+			cfg.ExportClasses = f.BoolValue()
+		case flagFold:
+			v.Set("fold", flagFoldVal.String())
+		case flagRedact:
+			v.Set("redact.enabled", f.BoolValue())
+			redactFlagSet = true
+		case flagSummary:
+			v.Set("summary", f.BoolValue())
+		case flagIdentityColors:
+			v.Set("identitycolors", f.BoolValue())
+		case flagExplainTree:
+			v.Set("explaintree", f.BoolValue())
+		case flagRolloutProgress:
+			v.Set("rolloutprogress", f.BoolValue())
+		case flagDecodeSecrets:
+			decodeSecrets = f.BoolValue()
+		case flagOrdered:
+			v.Set("orderedoutput", f.BoolValue())
 		default:
 			cfg.ArgsPassthrough = append(cfg.ArgsPassthrough, s)
 		}
@@ -121,6 +179,12 @@ func ResolveConfigViper(inputArgs []string, v *viper.Viper) (*Config, error) {
 		return nil, err
 	}
 	cfg.Config = newCfg
+	cfg.DecodeSecrets = decodeSecrets
+
+	if !redactFlagSet && !cfg.Redact.Enabled && len(cfg.Redact.Contexts) > 0 {
+		// e.g always redact when using a production cluster
+		cfg.Redact.Enabled = stringutil.MatchesAnyGlob(kubectl.CurrentContext(cfg.ArgsPassthrough), cfg.Redact.Contexts)
+	}
 
 	return cfg, nil
 }
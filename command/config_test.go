@@ -6,6 +6,7 @@ import (
 
 	"github.com/kubecolor/kubecolor/config"
 	"github.com/kubecolor/kubecolor/config/testconfig"
+	"github.com/kubecolor/kubecolor/internal/export"
 	"github.com/kubecolor/kubecolor/testutil"
 )
 
@@ -26,6 +27,8 @@ func Test_ResolveConfig(t *testing.T) {
 					Paging:            config.PagingDefault,
 					Theme:             *testconfig.DarkTheme,
 					Preset:            config.PresetDark,
+					Redact:            config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:        config.DefaultAllocation,
 				},
 				ArgsPassthrough: []string{"get", "pods"},
 				ForceColor:      ColorLevelUnset,
@@ -41,6 +44,8 @@ func Test_ResolveConfig(t *testing.T) {
 					Paging:            config.PagingDefault,
 					Theme:             *testconfig.LightTheme,
 					Preset:            config.PresetLight,
+					Redact:            config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:        config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelAuto,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -57,6 +62,8 @@ func Test_ResolveConfig(t *testing.T) {
 					Paging:            config.PagingDefault,
 					Theme:             *testconfig.DarkTheme,
 					Preset:            config.PresetDark,
+					Redact:            config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:        config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelNone,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -73,6 +80,8 @@ func Test_ResolveConfig(t *testing.T) {
 					Paging:            config.PagingDefault,
 					Theme:             *testconfig.DarkTheme,
 					Preset:            config.PresetDark,
+					Redact:            config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:        config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelUnset,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -84,10 +93,12 @@ func Test_ResolveConfig(t *testing.T) {
 			env:  map[string]string{"KUBECOLOR_LIGHT_BACKGROUND": "true"},
 			expectedConf: &Config{
 				Config: &config.Config{
-					Kubectl: "kubectl",
-					Paging:  config.PagingDefault,
-					Theme:   *testconfig.LightTheme,
-					Preset:  config.PresetLight,
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.LightTheme,
+					Preset:     config.PresetLight,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelUnset,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -99,10 +110,12 @@ func Test_ResolveConfig(t *testing.T) {
 			env:  map[string]string{"KUBECOLOR_FORCE_COLORS": "true"},
 			expectedConf: &Config{
 				Config: &config.Config{
-					Kubectl: "kubectl",
-					Paging:  config.PagingDefault,
-					Theme:   *testconfig.DarkTheme,
-					Preset:  config.PresetDark,
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelAuto,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -114,10 +127,12 @@ func Test_ResolveConfig(t *testing.T) {
 			env:  map[string]string{"KUBECOLOR_FORCE_COLORS": "truecolor"},
 			expectedConf: &Config{
 				Config: &config.Config{
-					Kubectl: "kubectl",
-					Paging:  config.PagingDefault,
-					Theme:   *testconfig.DarkTheme,
-					Preset:  config.PresetDark,
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
 				},
 				ForceColor:      ColorLevelTrueColor,
 				ArgsPassthrough: []string{"get", "pods"},
@@ -132,11 +147,13 @@ func Test_ResolveConfig(t *testing.T) {
 			},
 			expectedConf: &Config{
 				Config: &config.Config{
-					Kubectl: "kubectl",
-					Pager:   "most",
-					Paging:  config.PagingAuto,
-					Theme:   *testconfig.DarkTheme,
-					Preset:  config.PresetDark,
+					Kubectl:    "kubectl",
+					Pager:      "most",
+					Paging:     config.PagingAuto,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
 				},
 				ArgsPassthrough: []string{"get", "pods"},
 			},
@@ -149,10 +166,168 @@ func Test_ResolveConfig(t *testing.T) {
 			},
 			expectedConf: &Config{
 				Config: &config.Config{
-					Kubectl: "kubectl",
-					Paging:  config.PagingNever,
-					Theme:   *testconfig.DarkTheme,
-					Preset:  config.PresetDark,
+					Kubectl:    "kubectl",
+					Paging:     config.PagingNever,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+				},
+				ArgsPassthrough: []string{"get", "pods"},
+			},
+		},
+		{
+			name: "Ordered output flag overwrites env",
+			args: []string{"--kubecolor-ordered-output", "get", "pods"},
+			env: map[string]string{
+				"KUBECOLOR_ORDERED_OUTPUT": "false",
+			},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:       "kubectl",
+					Paging:        config.PagingDefault,
+					Theme:         *testconfig.DarkTheme,
+					Preset:        config.PresetDark,
+					Redact:        config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:    config.DefaultAllocation,
+					OrderedOutput: true,
+				},
+				ArgsPassthrough: []string{"get", "pods"},
+			},
+		},
+		{
+			name: "Export flag without value defaults to html",
+			args: []string{"get", "pods", "--kubecolor-export"},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+				},
+				Export:          export.FormatHTML,
+				ArgsPassthrough: []string{"get", "pods"},
+			},
+		},
+		{
+			name: "Export flag with value",
+			args: []string{"get", "pods", "--kubecolor-export=svg"},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+				},
+				Export:          export.FormatSVG,
+				ArgsPassthrough: []string{"get", "pods"},
+			},
+		},
+		{
+			name: "Export classes flag",
+			args: []string{"get", "pods", "--kubecolor-export", "--kubecolor-export-classes"},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+				},
+				Export:          export.FormatHTML,
+				ExportClasses:   true,
+				ArgsPassthrough: []string{"get", "pods"},
+			},
+		},
+		{
+			name: "Redact flag overwrites env",
+			args: []string{"get", "secrets", "-o", "yaml", "--kubecolor-redact"},
+			env: map[string]string{
+				"KUBECOLOR_REDACT_ENABLED": "false",
+			},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Enabled: true, Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+				},
+				ArgsPassthrough: []string{"get", "secrets", "-o", "yaml"},
+			},
+		},
+		{
+			name: "Summary flag overwrites env",
+			args: []string{"get", "pods", "-A", "--kubecolor-summary"},
+			env: map[string]string{
+				"KUBECOLOR_SUMMARY": "false",
+			},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+					Summary:    true,
+				},
+				ArgsPassthrough: []string{"get", "pods", "-A"},
+			},
+		},
+		{
+			name: "Decode secrets flag",
+			args: []string{"get", "secrets", "-o", "yaml", "--kubecolor-decode-secrets"},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:       "kubectl",
+					Paging:        config.PagingDefault,
+					Theme:         *testconfig.DarkTheme,
+					Preset:        config.PresetDark,
+					Redact:        config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation:    config.DefaultAllocation,
+					DecodeSecrets: true,
+				},
+				ArgsPassthrough: []string{"get", "secrets", "-o", "yaml"},
+			},
+		},
+		{
+			name: "Fold flag overwrites env",
+			args: []string{"get", "pods", "-o", "yaml", "--kubecolor-fold=managedFields,lastApplied"},
+			env: map[string]string{
+				"KUBECOLOR_FOLD": "blobs",
+			},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+					Fold:       config.Fold{config.FoldLastApplied, config.FoldManagedFields},
+				},
+				ArgsPassthrough: []string{"get", "pods", "-o", "yaml"},
+			},
+		},
+		{
+			name: "Fold flag without value folds all",
+			args: []string{"get", "pods", "--kubecolor-fold"},
+			expectedConf: &Config{
+				Config: &config.Config{
+					Kubectl:    "kubectl",
+					Paging:     config.PagingDefault,
+					Theme:      *testconfig.DarkTheme,
+					Preset:     config.PresetDark,
+					Redact:     config.Redact{Keys: config.DefaultRedactKeys},
+					Allocation: config.DefaultAllocation,
+					Fold:       config.Fold{config.FoldBlobs, config.FoldLastApplied, config.FoldManagedFields},
 				},
 				ArgsPassthrough: []string{"get", "pods"},
 			},
@@ -176,3 +351,26 @@ func Test_ResolveConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResolveConfig_redactContexts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want bool
+	}{
+		{name: "matching context", args: []string{"get", "secrets", "--context=prod-eu"}, want: true},
+		{name: "other context", args: []string{"get", "secrets", "--context", "dev"}, want: false},
+		{name: "flag overrides context", args: []string{"get", "secrets", "--context=prod-eu", "--kubecolor-redact=false"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Clearenv()
+			v := config.NewViper()
+			v.Set("redact.contexts", []string{"prod-*"})
+
+			conf, err := ResolveConfigViper(tt.args, v)
+			testutil.MustNoError(t, err)
+			testutil.Equal(t, tt.want, conf.Redact.Enabled)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+// passthroughReader keeps track of how far a printer has read its input,
+// so the output can still be written uncolored if the printer fails,
+// e.g by panicking or by hitting [bufio.ErrTooLong].
+//
+// It hands out at most one line per read, so a printer using a
+// [bufio.Scanner] has only read up to the end of the line it's working on.
+// When the printer fails, the output continues from the start of that line.
+// Lines the printer kept in its own buffer before failing, such as the
+// hidden lines of a folded value, are not written again.
+type passthroughReader struct {
+	r io.Reader
+
+	// readMu is held during reads, so the remaining output is written in order
+	// even if a printer's scanner goroutine is still reading.
+	readMu sync.Mutex
+	// buf is the buffer for reading from r, and is only used while holding readMu.
+	buf []byte
+
+	mu sync.Mutex
+	// pending is the bytes read from r but not yet given to the printer.
+	pending []byte
+	// line is the bytes of the line the printer is on, which may be incomplete.
+	line bytes.Buffer
+	// lineEnded is true if line ends with a newline, so the next read starts a new line.
+	lineEnded bool
+	readErr   error
+	done      bool
+	failed    bool
+}
+
+// ensures it implements the interface
+var _ io.Reader = &passthroughReader{}
+
+func newPassthroughReader(r io.Reader) *passthroughReader {
+	return &passthroughReader{r: r}
+}
+
+// Read implements [io.Reader].
+func (r *passthroughReader) Read(b []byte) (int, error) {
+	r.readMu.Lock()
+	defer r.readMu.Unlock()
+
+	r.mu.Lock()
+	failed := r.failed
+	fill := len(r.pending) == 0 && r.readErr == nil
+	r.mu.Unlock()
+	if failed {
+		return 0, io.EOF
+	}
+
+	if fill {
+		if r.buf == nil {
+			r.buf = make([]byte, 32*1024)
+		}
+		n, err := r.r.Read(r.buf)
+		r.mu.Lock()
+		r.pending = r.buf[:n]
+		r.readErr = err
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pending) == 0 {
+		if r.readErr != nil {
+			r.done = true
+		}
+		return 0, r.readErr
+	}
+	chunk := r.pending
+	if index := bytes.IndexByte(chunk, '\n'); index != -1 {
+		chunk = chunk[:index+1]
+	}
+	n := copy(b, chunk)
+	r.pending = r.pending[n:]
+	if r.lineEnded {
+		r.line.Reset()
+	}
+	r.line.Write(b[:n])
+	r.lineEnded = n > 0 && b[n-1] == '\n'
+	return n, nil
+}
+
+// Done returns true if the reader has been read until EOF or until error.
+func (r *passthroughReader) Done() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.done
+}
+
+// WriteRemaining writes the raw bytes from the start of the line the printer
+// was on, as well as the rest of the underlying reader, to w.
+func (r *passthroughReader) WriteRemaining(w io.Writer) error {
+	r.mu.Lock()
+	r.failed = true
+	r.mu.Unlock()
+
+	r.readMu.Lock()
+	defer r.readMu.Unlock()
+
+	r.mu.Lock()
+	if _, err := r.line.WriteTo(w); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	if _, err := w.Write(r.pending); err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	r.pending = nil
+	readErr := r.readErr
+	r.mu.Unlock()
+
+	if readErr != nil {
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		return readErr
+	}
+	if _, err := io.Copy(w, r.r); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/printer"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestPassthroughReader_panic(t *testing.T) {
+	input := "line 1\nline 2\nline 3\nline 4\n"
+	r := newPassthroughReader(strings.NewReader(input))
+
+	var out bytes.Buffer
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if scanner.Text() == "line 3" {
+				panic("test")
+			}
+			fmt.Fprintf(&out, "colored %s\n", scanner.Text())
+		}
+	}()
+
+	testutil.MustNoError(t, r.WriteRemaining(&out))
+	testutil.Equal(t, "colored line 1\ncolored line 2\nline 3\nline 4\n", out.String())
+}
+
+func TestPassthroughReader_scannerError(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	input := "short\n" + long + "\nafter\n"
+	r := newPassthroughReader(strings.NewReader(input))
+
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 50)
+	for scanner.Scan() {
+		fmt.Fprintf(&out, "colored %s\n", scanner.Text())
+	}
+	if scanner.Err() != bufio.ErrTooLong {
+		t.Fatalf("expected bufio.ErrTooLong, got %v", scanner.Err())
+	}
+
+	testutil.Equal(t, false, r.Done(), "done")
+	testutil.MustNoError(t, r.WriteRemaining(&out))
+	testutil.Equal(t, "colored short\n"+long+"\nafter\n", out.String())
+}
+
+func TestPassthroughReader_success(t *testing.T) {
+	r := newPassthroughReader(strings.NewReader("foo\nbar\n"))
+
+	var out bytes.Buffer
+	io.Copy(&out, r)
+
+	testutil.Equal(t, true, r.Done(), "done")
+	testutil.Equal(t, "foo\nbar\n", out.String())
+}
+
+// panicWriter panics when a line containing trigger is written,
+// to make a printer fail in the middle of its output.
+type panicWriter struct {
+	w       io.Writer
+	trigger string
+}
+
+func (w panicWriter) Write(b []byte) (int, error) {
+	if bytes.Contains(b, []byte(w.trigger)) {
+		panic("test")
+	}
+	return w.w.Write(b)
+}
+
+func TestPassthroughReader_printerChangesLineCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		printer printer.Printer
+		input   string
+		want    string
+	}{
+		{
+			name:    "fold",
+			printer: &printer.YAMLPrinter{Theme: &config.Theme{}, Fold: config.Fold{config.FoldManagedFields}},
+			input: testutil.NewHereDoc(`
+				metadata:
+				  managedFields:
+				  - manager: kubectl
+				    operation: Update
+				  name: foo
+				  namespace: trigger
+				spec: {}
+				`),
+			want: testutil.NewHereDoc(`
+				metadata:
+				  managedFields: # … (2 lines hidden)
+				  name: foo
+				  namespace: trigger
+				spec: {}
+				`),
+		},
+		{
+			name:    "decode secrets",
+			printer: &printer.YAMLPrinter{Theme: &config.Theme{}, DecodeSecrets: true},
+			input: testutil.NewHereDoc(`
+				apiVersion: v1
+				data:
+				  password: aHVudGVyMg==
+				  user: YWRtaW4=
+				kind: Secret
+				metadata:
+				  name: trigger
+				`),
+			want: testutil.NewHereDoc(`
+				apiVersion: v1
+				data:
+				  password: aHVudGVyMg== # hunter2
+				  user: YWRtaW4= # admin
+				kind: Secret
+				metadata:
+				  name: trigger
+				`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPassthroughReader(strings.NewReader(tt.input))
+
+			var out bytes.Buffer
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Fatal("expected panic")
+					}
+				}()
+				tt.printer.Print(r, panicWriter{w: &out, trigger: "trigger"})
+			}()
+
+			testutil.MustNoError(t, r.WriteRemaining(&out))
+			testutil.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -11,8 +12,11 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/gookit/color"
+	gookit "github.com/gookit/color"
 	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/export"
+	"github.com/kubecolor/kubecolor/internal/linemerge"
 	"github.com/kubecolor/kubecolor/kubectl"
 	"github.com/kubecolor/kubecolor/printer"
 	"github.com/mattn/go-colorable"
@@ -28,6 +32,8 @@ var (
 type Printers struct {
 	FullColoredPrinter printer.Printer
 	ErrorPrinter       printer.Printer
+	// Summary is printed after the output, or nil if disabled.
+	Summary *printer.Summary
 }
 
 type pagerPipe struct {
@@ -36,18 +42,38 @@ type pagerPipe struct {
 }
 
 // This is defined here to be replaced in test
-var getPrinters = func(subcommandInfo *kubectl.SubcommandInfo, cfg *config.Config, version string) *Printers {
+var getPrinters = func(subcommandInfo *kubectl.SubcommandInfo, cfg *config.Config, redactor *printer.Redactor, version string) *Printers {
+	var summary *printer.Summary
+	// Based on the color level instead of the terminal, so --force-colors
+	// also shows it when the output is piped
+	if cfg.Summary && gookit.TermColorLevel() != terminfo.ColorLevelNone {
+		summary = &printer.Summary{Theme: &cfg.Theme}
+	}
 	return &Printers{
 		FullColoredPrinter: &printer.KubectlOutputColoredPrinter{
 			SubcommandInfo:    subcommandInfo,
 			Recursive:         subcommandInfo.Recursive,
 			ObjFreshThreshold: cfg.ObjFreshThreshold,
+			Allocation:        cfg.Allocation,
 			Theme:             &cfg.Theme,
 			KubecolorVersion:  version,
+			Fold:              cfg.Fold,
+			Redactor:          redactor,
+			DecodeSecrets:     cfg.DecodeSecrets,
+			RowRules:          cfg.RowRules,
+			Summary:           summary,
+			Identity:          printer.NewIdentityColors(cfg.IdentityColors, &cfg.Theme),
+			ExplainTree:       cfg.ExplainTree,
+			Terminal:          isOutputTerminal(),
+			ProtectedContexts: cfg.ProtectedContexts,
+			RolloutProgress:   cfg.RolloutProgress && isOutputTerminal(),
+			Verbs:             cfg.Verbs,
 		},
 		ErrorPrinter: &printer.StderrPrinter{
-			Theme: &cfg.Theme,
+			Theme:    &cfg.Theme,
+			Redactor: redactor,
 		},
+		Summary: summary,
 	}
 }
 
@@ -58,9 +84,10 @@ func Run(rawArgs []string, version string) error {
 	}
 	args := cfg.ArgsPassthrough
 
-	subcommandInfo := kubectl.InspectSubcommandInfo(args, kubectl.DefaultPluginHandler{})
+	dialect := kubectl.DialectFromCommand(cfg.Kubectl)
+	subcommandInfo := dialect.InspectSubcommandInfo(args, kubectl.DefaultPluginHandler{})
 
-	slog.Debug("Parsed command", "subcommand", subcommandInfo.Subcommand,
+	slog.Debug("Parsed command", "dialect", dialect, "subcommand", subcommandInfo.Subcommand,
 		"supportsColoring", subcommandInfo.SupportsColoring(),
 		"supportsPager", subcommandInfo.SupportsPager())
 
@@ -75,18 +102,26 @@ func Run(rawArgs []string, version string) error {
 		return nil
 	}
 
-	if cfg.Paging == config.PagingAuto && isOutputTerminal() && subcommandInfo.SupportsPager() {
+	exporting := cfg.Export != export.FormatNone && subcommandInfo.SupportsColoring()
+
+	usingPager := false
+	if cfg.Paging == config.PagingAuto && !exporting && isOutputTerminal() && subcommandInfo.SupportsPager() {
 		pipe, err := runPager(cfg.Pager)
 		if err != nil {
 			err = fmt.Errorf("failed to run pager: %w", err)
 			slog.Error(err.Error())
 		} else if pipe != nil {
 			Stdout = pipe.Writer()
+			usingPager = true
 			defer pipe.Close()
 		}
 	}
 
 	switch {
+	// Exported HTML and SVG supports all colors, no matter the terminal
+	case exporting:
+		gookit.ForceSetColorLevel(terminfo.ColorLevelMillions)
+
 	// Skip if special subcommand (e.g "kubectl exec")
 	case !subcommandInfo.SupportsColoring(),
 		// Skip if explicitly setting --force-colors=none
@@ -98,8 +133,11 @@ func Run(rawArgs []string, version string) error {
 
 		if subcommandInfo.Subcommand == kubectl.Version {
 			// continue with custom printer, but without colors
-			color.ForceSetColorLevel(terminfo.ColorLevelNone)
+			gookit.ForceSetColorLevel(terminfo.ColorLevelNone)
 		} else {
+			if cfg.CapturePath != "" {
+				slog.Error("Nothing was captured, as output is not colored. Try adding --force-colors")
+			}
 			// when we shan't colorize, just run command and return
 			return execWithoutColors(cfg, args)
 		}
@@ -108,20 +146,20 @@ func Run(rawArgs []string, version string) error {
 		// gookit/color defaults to 8-bit colors when FORCE_COLOR is set.
 		// We don't want this behaviour.
 		os.Unsetenv("FORCE_COLOR")
-		color.DetectColorLevel()
+		gookit.DetectColorLevel()
 
-		if color.TermColorLevel() == terminfo.ColorLevelNone && os.Getenv("COLORTERM") == "" {
+		if gookit.TermColorLevel() == terminfo.ColorLevelNone && os.Getenv("COLORTERM") == "" {
 			// gookit/color package couldn't determine the color support of the terminal.
 			// The user did provide a `--force-colors` setting,
 			// so let's just fallback to basic ANSI color codes to be safe.
-			color.ForceSetColorLevel(terminfo.ColorLevelBasic)
+			gookit.ForceSetColorLevel(terminfo.ColorLevelBasic)
 		}
 
 	default:
-		color.ForceSetColorLevel(cfg.ForceColor.TerminfoColorLevel())
+		gookit.ForceSetColorLevel(cfg.ForceColor.TerminfoColorLevel())
 	}
 
-	// Computes color code caches, AFTER the [color.DetectColorLevel] and [color.ForceSetColorLevel]
+	// Computes color code caches, AFTER the [gookit.DetectColorLevel] and [gookit.ForceSetColorLevel]
 	cfg.Theme.ComputeCache()
 
 	stdoutReader, stderrReader, err := execWithReaders(cfg, args)
@@ -129,35 +167,126 @@ func Run(rawArgs []string, version string) error {
 		return err
 	}
 
-	// make buffer to be used in defer recover()
-	errBuf := new(bytes.Buffer)
-	errBufReader := io.TeeReader(stderrReader, errBuf)
-
-	printers := getPrinters(subcommandInfo, cfg.Config, version)
+	// Shared by the printers, including the ones for --kubecolor-capture
+	redactor := printer.NewRedactor(cfg.Redact, &cfg.Theme)
+	printers := getPrinters(subcommandInfo, cfg.Config, redactor, version)
+
+	var (
+		stdoutIn  io.Reader = stdoutReader
+		stderrIn  io.Reader = stderrReader
+		stdoutOut io.Writer = Stdout
+		stderrOut io.Writer = Stderr
+		capture   *Capture
+		exportBuf bytes.Buffer
+		marks     *color.Marks
+
+		merger       *linemerge.Merger
+		stdoutStream *linemerge.Stream
+		stderrStream *linemerge.Stream
+	)
+	if cfg.CapturePath != "" {
+		capture = &Capture{
+			Args:       args,
+			Config:     cfg.Config,
+			Version:    version,
+			ColorLevel: captureColorLevel(),
+			Printers:   getPrinters(subcommandInfo, cfg.Config, redactor, version),
+		}
+		stdoutIn = io.TeeReader(stdoutIn, &capture.RawStdout)
+		stderrIn = io.TeeReader(stderrIn, &capture.RawStderr)
+	}
+	if exporting {
+		// Colors are exported from the marked colors, instead of parsing the ANSI codes
+		marks = &color.Marks{}
+		color.UseMarks(marks)
+		defer color.UseMarks(nil)
+		stdoutOut = &exportBuf
+		stderrOut = marks.ANSIWriter(stderrOut)
+	}
+	if cfg.OrderedOutput {
+		if usingPager || exporting || !isOutputTerminal() || !isErrorTerminal() {
+			// Keep stdout and stderr separate, so redirecting either one still works
+			slog.Debug("Skipping ordered output, as stdout or stderr is redirected")
+		} else {
+			merger = linemerge.New()
+			stdoutStream = merger.NewStream(stdoutIn, Stdout)
+			stderrStream = merger.NewStream(stderrIn, Stderr)
+			stdoutIn, stdoutOut = stdoutStream, stdoutStream
+			stderrIn, stderrOut = stderrStream, stderrStream
+		}
+	}
 
 	wg := &sync.WaitGroup{}
 
 	var closeErr error
 	wg.Go(func() {
 		defer func() { closeErr = stdoutReader.Close() }()
+		if stdoutStream != nil {
+			defer stdoutStream.Close()
+		}
+		passthrough := newPassthroughReader(stdoutIn)
 		defer func() {
-			if r := recover(); r != nil {
+			r := recover()
+			if r != nil {
 				slog.Error("Recovered from panic", "error", r)
-				os.Stdout.Write(errBuf.Bytes())
+			}
+			// Printer stopped early, e.g on a too long line
+			if r != nil || !passthrough.Done() {
+				slog.Debug("Coloring failed, printing the rest of the output without colors")
+				if err := passthrough.WriteRemaining(stdoutOut); err != nil {
+					slog.Error("Failed to print uncolored output.", "error", err)
+				}
 			}
 		}()
 
 		// This can panic when kubecolor has bug, so recover in defer
-		printers.FullColoredPrinter.Print(stdoutReader, Stdout)
+		printers.FullColoredPrinter.Print(passthrough, stdoutOut)
 	})
 
 	wg.Go(func() {
 		defer stderrReader.Close()
+		if stderrStream != nil {
+			defer stderrStream.Close()
+		}
 		// This will unlikely panic
-		printers.ErrorPrinter.Print(errBufReader, Stderr)
+		printers.ErrorPrinter.Print(stderrIn, stderrOut)
 	})
 
 	wg.Wait()
+	if merger != nil {
+		merger.Wait()
+	}
+	color.UseMarks(nil)
+
+	if printers.Summary != nil {
+		// Printed to stderr, so piping the output still works,
+		// unless the output is shown in a pager anyway
+		summaryOut := Stderr
+		if usingPager {
+			summaryOut = Stdout
+		}
+		printers.Summary.Print(summaryOut)
+	}
+
+	if exporting {
+		opts := export.Options{
+			Marks:   marks,
+			Classes: cfg.ExportClasses,
+			Palette: export.PaletteForPreset(cfg.Preset),
+			Title:   "kubectl " + strings.Join(args, " "),
+		}
+		if err := export.Write(Stdout, cfg.Export, exportBuf.String(), opts); err != nil {
+			return fmt.Errorf("export as %s: %w", cfg.Export, err)
+		}
+	}
+
+	if capture != nil {
+		if err := capture.WriteFile(cfg.CapturePath); err != nil {
+			slog.Error("Failed to write capture file.", "error", err)
+		} else {
+			slog.Debug("Wrote capture file", "path", cfg.CapturePath)
+		}
+	}
 
 	return closeErr
 }
@@ -227,7 +356,20 @@ func getStdinOverrideReader(stdinOverride string) (io.ReadCloser, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read file specified by --kubecolor-stdin: %w", err)
 	}
-	return file, nil
+	reader := bufio.NewReader(file)
+	if firstLine, _ := reader.Peek(len(captureHeaderSeparator)); string(firstLine) == captureHeaderSeparator {
+		// File from --kubecolor-capture (or from ./test/corpus)
+		defer file.Close()
+		input, err := readCaptureInput(reader)
+		if err != nil {
+			return nil, fmt.Errorf("read file specified by --kubecolor-stdin: %w", err)
+		}
+		return io.NopCloser(strings.NewReader(input)), nil
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{reader, file}, nil
 }
 
 type nopReadCloser struct{}
@@ -317,3 +459,8 @@ func runPager(pager string) (*pagerPipe, error) {
 var isOutputTerminal = func() bool {
 	return isatty.IsTerminal(os.Stdout.Fd()) || isatty.IsCygwinTerminal(os.Stdout.Fd())
 }
+
+// mocked in unit tests
+var isErrorTerminal = func() bool {
+	return isatty.IsTerminal(os.Stderr.Fd()) || isatty.IsCygwinTerminal(os.Stderr.Fd())
+}
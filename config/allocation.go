@@ -0,0 +1,15 @@
+package config
+
+// DefaultAllocation is the default value of [Config.Allocation].
+var DefaultAllocation = Allocation{
+	Requests: 80,
+	Limits:   90,
+}
+
+// Allocation holds the thresholds for coloring the percentages of allocated
+// resources in "kubectl describe node", e.g "3800m (95%)". Percentages
+// above 100% are always colored as overcommitted.
+type Allocation struct {
+	Requests int `jsonschema:"default=80"` // Percentage of the requests from which they're colored with theme.describe.allocationHigh
+	Limits   int `jsonschema:"default=90"` // Percentage of the limits from which they're colored with theme.describe.allocationHigh
+}
@@ -16,6 +16,9 @@ type ColorCode interface {
 type Color struct {
 	Source string
 	Parsed []ColorCode
+	// Key is the theme config key this color is used for, e.g "theme.data.string".
+	// It's set when computing the theme, and only used when exporting, see [Marks].
+	Key string
 
 	cached     bool
 	cachedCode string
@@ -50,28 +53,53 @@ func (c Color) ANSICode() string {
 	return c.cachedCode
 }
 
-// Render returns the string wrapped in color codes from this color.
-func (c Color) Render(s string) string {
+// renderCode returns the code to render with, which is a marker instead
+// of the ANSI code while [Marks] are in use.
+func (c Color) renderCode() string {
 	if !c.cached {
 		c.ComputeCache()
 	}
 	if c.cachedCode == "" {
+		return ""
+	}
+	if marks := activeMarks.Load(); marks != nil {
+		return marks.marker(c)
+	}
+	return c.cachedCode
+}
+
+// Render returns the string wrapped in color codes from this color.
+func (c Color) Render(s string) string {
+	code := c.renderCode()
+	if code == "" {
 		return s
 	}
 	if strings.ContainsRune(s, '\033') {
-		return c.renderInject(s)
+		return renderInject(code, s)
 	}
-	return color.RenderString(c.cachedCode, s)
+	return color.RenderString(code, s)
 }
 
-func (c Color) renderInject(s string) string {
+func renderInject(code, s string) string {
 	if strings.HasPrefix(s, "\033[") &&
 		strings.HasSuffix(s, "\033[0m") &&
 		strings.Count(s, "\033[") == 2 {
 		// If full string is colored, then doesn't matter if we add colors
 		return s
 	}
-	return color.RenderString(c.cachedCode, s)
+	return color.RenderString(code, s)
+}
+
+// RenderBeneath returns the string wrapped in color codes from this color,
+// where any colors already in the string take precedence. Unlike [Color.Render],
+// this color is always re-applied after the colored parts of the string,
+// so that e.g a background color spans the whole string.
+func (c Color) RenderBeneath(s string) string {
+	code := c.renderCode()
+	if code == "" {
+		return s
+	}
+	return color.RenderString(code, s)
 }
 
 // Sprint returns the stringified args (concatenated right after each other)
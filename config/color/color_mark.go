@@ -0,0 +1,125 @@
+package color
+
+import (
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Marks keeps track of the colors rendered while it's in use (see [UseMarks]),
+// so the output can be exported with the parsed color codes of each color,
+// and the theme key it came from, instead of parsing the ANSI codes.
+//
+// While in use, colors render with a marker in place of their ANSI code,
+// e.g "\033[?3m" for the color with ID 3. The "?" makes it a private
+// escape sequence that no printer or kubectl writes on its own.
+type Marks struct {
+	mu     sync.Mutex
+	marks  []Mark
+	lookup map[string]int
+}
+
+// Mark is a color that was rendered while [Marks] was in use.
+type Mark struct {
+	// Key is the theme config key of the color, e.g "theme.data.string",
+	// or empty if the color isn't from the theme.
+	Key    string
+	Parsed []ColorCode
+}
+
+var activeMarks atomic.Pointer[Marks]
+
+// UseMarks makes colors render with markers from m instead of ANSI codes.
+// Call it with nil to go back to ANSI codes.
+func UseMarks(m *Marks) {
+	activeMarks.Store(m)
+}
+
+// Lookup returns the color of a marker, where params are the parameters
+// of the escape sequence, e.g "?3" for "\033[?3m".
+func (m *Marks) Lookup(params string) (Mark, bool) {
+	idStr, ok := strings.CutPrefix(params, "?")
+	if !ok {
+		return Mark{}, false
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return Mark{}, false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if id < 0 || id >= len(m.marks) {
+		return Mark{}, false
+	}
+	return m.marks[id], true
+}
+
+// Keyed returns the marked colors that are from the theme, in the order
+// they were first rendered.
+func (m *Marks) Keyed() []Mark {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var marks []Mark
+	for _, mark := range m.marks {
+		if mark.Key != "" {
+			marks = append(marks, mark)
+		}
+	}
+	return marks
+}
+
+func (m *Marks) marker(c Color) string {
+	var sb strings.Builder
+	sb.WriteString(c.Key)
+	for _, p := range c.Parsed {
+		sb.WriteByte(';')
+		sb.WriteString(p.Code())
+	}
+	lookupKey := sb.String()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id, ok := m.lookup[lookupKey]
+	if !ok {
+		if m.lookup == nil {
+			m.lookup = map[string]int{}
+		}
+		id = len(m.marks)
+		m.marks = append(m.marks, Mark{Key: c.Key, Parsed: c.Parsed})
+		m.lookup[lookupKey] = id
+	}
+	return "?" + strconv.Itoa(id)
+}
+
+var markerRegex = regexp.MustCompile(`\033\[(\?\d+)m`)
+
+// ANSIWriter returns a writer that replaces the markers with ANSI codes,
+// for output that's still written to the terminal while m is in use,
+// such as stderr. Markers split between two writes are not replaced.
+func (m *Marks) ANSIWriter(w io.Writer) io.Writer {
+	return &ansiWriter{w: w, marks: m}
+}
+
+type ansiWriter struct {
+	w     io.Writer
+	marks *Marks
+}
+
+// Write implements [io.Writer].
+func (w *ansiWriter) Write(b []byte) (int, error) {
+	replaced := markerRegex.ReplaceAllFunc(b, func(marker []byte) []byte {
+		mark, ok := w.marks.Lookup(string(markerRegex.FindSubmatch(marker)[1]))
+		if !ok {
+			return marker
+		}
+		c := Color{Parsed: mark.Parsed}
+		return []byte("\033[" + c.ANSICode() + "m")
+	})
+	if _, err := w.w.Write(replaced); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -38,7 +38,7 @@ func parseColorOp(s string) color.Color {
 		return color.OpReset
 	case "bold", "b":
 		return color.Bold
-	case "fuzzy":
+	case "fuzzy", "dim", "faint":
 		return color.OpFuzzy
 	case "italic", "i":
 		return color.OpItalic
@@ -1,6 +1,7 @@
 package color
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -28,6 +29,11 @@ func TestParseColor(t *testing.T) {
 			input:    "underline",
 			wantCode: "4",
 		},
+		{
+			name:     "op/dim",
+			input:    "dim",
+			wantCode: "2",
+		},
 		{
 			name:     "fg/long hex without hash",
 			input:    "ffff22",
@@ -119,3 +125,34 @@ func TestRender_onColoredText(t *testing.T) {
 	s2 := surrounding.Render(s)
 	testutil.Equal(t, "\033[33mprefix \033[36mhighlighted\033[0m\033[33m suffix\033[0m", s2, "with surrounding color")
 }
+
+func TestRenderBeneath(t *testing.T) {
+	highlight := MustParse("cyan")
+	s := highlight.Render("highlighted")
+
+	testutil.Equal(t, s, MustParse("bg=red").Render(s), "render skips fully colored text")
+	testutil.Equal(t, "\033[41m\033[36mhighlighted\033[0m\033[41m\033[0m", MustParse("bg=red").RenderBeneath(s))
+}
+
+func TestMarks(t *testing.T) {
+	marks := &Marks{}
+	UseMarks(marks)
+	t.Cleanup(func() { UseMarks(nil) })
+
+	header := MustParse("bold")
+	header.Key = "theme.table.header"
+	s := header.Render("NAME") + " " + MustParse("#ff0000").Render("foo") + " " + header.Render("AGE")
+	testutil.Equal(t, "\033[?0mNAME\033[0m \033[?1mfoo\033[0m \033[?0mAGE\033[0m", s)
+
+	mark, ok := marks.Lookup("?1")
+	testutil.Equal(t, true, ok, "found mark")
+	testutil.Equal(t, MustParse("#ff0000").Parsed, mark.Parsed)
+	testutil.Equal(t, []Mark{{Key: "theme.table.header", Parsed: header.Parsed}}, marks.Keyed())
+
+	_, ok = marks.Lookup("1")
+	testutil.Equal(t, false, ok, "not a marker")
+
+	var buf bytes.Buffer
+	fmt.Fprint(marks.ANSIWriter(&buf), s)
+	testutil.Equal(t, "\033[1mNAME\033[0m \033[38;2;255;0;0mfoo\033[0m \033[1mAGE\033[0m", buf.String())
+}
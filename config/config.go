@@ -7,11 +7,13 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/internal/slogutil"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 )
 
 // PresetKey is the Viper config key to use in [viper.Viper.Set].
@@ -30,12 +32,51 @@ type Config struct {
 	Theme  Theme
 	Pager  string `jsonschema:"example=less -RF,less --RAW-CONTROL-CHARS --quit-if-one-screen,example=more"` // Command to use as pager
 	Paging Paging `jsonschema:"default=never"`                                                               // Whether to enable paging: "auto" or "never"
+
+	// Print stdout and stderr lines in the order kubectl wrote them, instead of coloring them independently.
+	// Only applies when both stdout and stderr are terminals.
+	OrderedOutput bool
+
+	// Hide noisy parts of "-o yaml" and "-o json" output behind a placeholder, e.g "managedFields,lastApplied" or "all".
+	// Only affects what is printed, so the output can't be used as a manifest anymore.
+	Fold Fold `jsonschema:"example=managedFields,example=all"`
+
+	Redact Redact
+
+	// Highlight the names of these contexts in "kubectl config get-contexts" and "kubectl config current-context",
+	// as glob patterns, e.g "prod-*".
+	ProtectedContexts []string `jsonschema:"example=prod-*"`
+
+	// Print a footer to stderr with counts per status in table output, e.g "180 Running · 3 CrashLoopBackOff",
+	// and counts per verb in e.g "kubectl apply" output.
+	Summary bool
+
+	// Styles for whole rows in table output, e.g to dim completed pods, or to highlight crashing pods.
+	RowRules []RowRule
+
+	// Color namespaces, nodes, and resource kinds with a color picked by their name from theme.identity.palette,
+	// so e.g the same namespace has the same color in "kubectl get", "kubectl events", and "kubectl logs --prefix".
+	IdentityColors bool
+
+	// Draw an in-place progress bar in "kubectl rollout status" when the output is a terminal.
+	RolloutProgress bool
+
+	// Extra verbs to color in the output of commands that change resources, e.g "kubectl taint" or "kubectl apply".
+	Verbs []VerbRule
+
+	// Thresholds for coloring the allocated resources in "kubectl describe node", e.g "cpu  3800m (95%)  8 (200%)".
+	Allocation Allocation
+
+	// Draw tree guides for the nesting of fields in "kubectl explain --recursive" output, e.g "├─ apiVersion".
+	ExplainTree bool
+
+	// Show the decoded value of base64 encoded Secret data. Only set by the
+	// --kubecolor-decode-secrets flag, so it's always an explicit choice.
+	DecodeSecrets bool `jsonschema:"-" mapstructure:"-"`
 }
 
 func NewViper() *viper.Viper {
-	v := viper.New()
-	v.SetConfigName("color")
-	v.SetConfigType("yaml")
+	v := NewDefaultViper()
 
 	v.AutomaticEnv()
 	v.SetEnvPrefix("KUBECOLOR")
@@ -45,13 +86,38 @@ func NewViper() *viper.Viper {
 
 	v.MustBindEnv("kubectl", "KUBECTL_COMMAND")
 	v.MustBindEnv("objfreshthreshold", "KUBECOLOR_OBJ_FRESH")
+	v.MustBindEnv("orderedoutput", "KUBECOLOR_ORDERED_OUTPUT")
+	v.MustBindEnv("fold", "KUBECOLOR_FOLD")
+	v.MustBindEnv("redact.enabled", "KUBECOLOR_REDACT_ENABLED")
+	v.MustBindEnv("summary", "KUBECOLOR_SUMMARY")
+	v.MustBindEnv("identitycolors", "KUBECOLOR_IDENTITY_COLORS")
+	v.MustBindEnv("explaintree", "KUBECOLOR_EXPLAIN_TREE")
+	v.MustBindEnv("rolloutprogress", "KUBECOLOR_ROLLOUT_PROGRESS")
+	// Lists are either comma separated, or in YAML flow style, e.g `[{status: error, style: red}]`
+	v.MustBindEnv("redact.contexts", "KUBECOLOR_REDACT_CONTEXTS")
+	v.MustBindEnv("protectedcontexts", "KUBECOLOR_PROTECTED_CONTEXTS")
+	v.MustBindEnv("rowrules", "KUBECOLOR_ROWRULES")
+	v.MustBindEnv("verbs", "KUBECOLOR_VERBS")
 	// NOTE: Don't bind PAGER here as it should be overwritten by the config file
 
+	return v
+}
+
+// NewDefaultViper returns a [viper.Viper] with only the default config,
+// without reading any environment variables.
+func NewDefaultViper() *viper.Viper {
+	v := viper.New()
+	v.SetConfigName("color")
+	v.SetConfigType("yaml")
+
 	v.SetDefault("kubectl", "kubectl")
 	// mapstructure doesn't like "type X string" values, so we have to convert it via string(...)
 	v.SetDefault(PresetKey, string(PresetDefault))
 	v.SetDefault("paging", string(PagingDefault))
 	v.SetDefault("pager", defaultPager())
+	v.SetDefault("redact.keys", DefaultRedactKeys)
+	v.SetDefault("allocation.requests", DefaultAllocation.Requests)
+	v.SetDefault("allocation.limits", DefaultAllocation.Limits)
 
 	return v
 }
@@ -99,12 +165,36 @@ func Unmarshal(v *viper.Viper) (*Config, error) {
 		mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
 			mapstructure.TextUnmarshallerHookFunc(),
+			stringToSliceHookFunc(),
 		))); err != nil {
 		return nil, err
 	}
 	return cfg, nil
 }
 
+// stringToSliceHookFunc decodes lists set by env vars, which are either
+// comma separated, e.g "prod-*,staging-*", or in YAML flow style,
+// e.g `[{status: error, style: red}]`.
+func stringToSliceHookFunc() mapstructure.DecodeHookFuncType {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+			return data, nil
+		}
+		s := strings.TrimSpace(reflect.ValueOf(data).String())
+		if s == "" {
+			return []any{}, nil
+		}
+		if !strings.HasPrefix(s, "[") {
+			return strings.Split(s, ","), nil
+		}
+		var list []any
+		if err := yaml.Unmarshal([]byte(s), &list); err != nil {
+			return nil, fmt.Errorf("parse list: %w", err)
+		}
+		return list, nil
+	}
+}
+
 func ApplyThemePreset(v *viper.Viper) error {
 	preset, err := ParsePreset(v.GetString(PresetKey))
 	if err != nil {
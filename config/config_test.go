@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/kubecolor/kubecolor/testutil"
@@ -60,3 +61,78 @@ func TestEnvVars_pager(t *testing.T) {
 	testutil.MustNoError(t, err)
 	testutil.Equal(t, PagingAuto, cfg.Paging)
 }
+
+func TestEnvVars_lists(t *testing.T) {
+	os.Clearenv()
+	testutil.Setenv(t, "KUBECOLOR_REDACT_CONTEXTS", "prod-*,staging-*")
+	testutil.Setenv(t, "KUBECOLOR_PROTECTED_CONTEXTS", "prod-*")
+	testutil.Setenv(t, "KUBECOLOR_ROWRULES", `[{status: error, style: "bg=#3a0000"}]`)
+	testutil.Setenv(t, "KUBECOLOR_VERBS", `[{"subcommand":"apply","verb":"pruned","color":"red"}]`)
+
+	cfg, err := Unmarshal(NewViper())
+	testutil.MustNoError(t, err)
+
+	testutil.Equal(t, []string{"prod-*", "staging-*"}, cfg.Redact.Contexts)
+	testutil.Equal(t, []string{"prod-*"}, cfg.ProtectedContexts)
+	testutil.Equal(t, 1, len(cfg.RowRules))
+	testutil.Equal(t, StatusLevelError, cfg.RowRules[0].Status)
+	testutil.Equal(t, "bg=#3a0000", cfg.RowRules[0].Style.Source)
+	testutil.Equal(t, []VerbRule{{Subcommand: "apply", Verb: "pruned", Color: "red"}}, cfg.Verbs)
+}
+
+func TestConfigFile_rowRules(t *testing.T) {
+	os.Clearenv()
+	v := NewViper()
+	testutil.MustNoError(t, v.ReadConfig(strings.NewReader(testutil.NewHereDoc(`
+		rowRules:
+		  - status: error
+		    style: bg=#3a0000
+		  - column: STATUS
+		    value: Completed
+		    style: dim
+	`))))
+
+	cfg, err := Unmarshal(v)
+	testutil.MustNoError(t, err)
+
+	testutil.Equal(t, 2, len(cfg.RowRules))
+	testutil.Equal(t, StatusLevelError, cfg.RowRules[0].Status)
+	testutil.Equal(t, "bg=#3a0000", cfg.RowRules[0].Style.Source)
+	testutil.Equal(t, "STATUS", cfg.RowRules[1].Column)
+	testutil.Equal(t, "Completed", cfg.RowRules[1].Value)
+	testutil.Equal(t, "dim", cfg.RowRules[1].Style.Source)
+}
+
+func TestConfigFile_verbs(t *testing.T) {
+	os.Clearenv()
+	v := NewViper()
+	testutil.MustNoError(t, v.ReadConfig(strings.NewReader(testutil.NewHereDoc(`
+		verbs:
+		  - subcommand: wait
+		    verb: condition met
+		    color: theme.base.success
+		  - verb: evicting
+		    color: yellow
+		    prefix: true
+	`))))
+
+	cfg, err := Unmarshal(v)
+	testutil.MustNoError(t, err)
+
+	testutil.Equal(t, 2, len(cfg.Verbs))
+	testutil.Equal(t, "wait", cfg.Verbs[0].Subcommand)
+	testutil.Equal(t, "condition met", cfg.Verbs[0].Verb)
+	testutil.Equal(t, "theme.base.success", cfg.Verbs[0].Color)
+	testutil.Equal(t, "", cfg.Verbs[1].Subcommand)
+	testutil.Equal(t, true, cfg.Verbs[1].Prefix)
+}
+
+func TestTheme_LookupColor(t *testing.T) {
+	theme := NewBaseTheme(PresetDark)
+	c, ok := theme.LookupColor("theme.Apply.Created")
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, theme.Apply.Created, c)
+
+	_, ok = theme.LookupColor("theme.apply.nonexistent")
+	testutil.Equal(t, false, ok)
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FoldTarget is a noisy part of "-o yaml" and "-o json" output
+// that can be hidden behind a placeholder.
+type FoldTarget string
+
+const (
+	// NOTE: When adding targets, remember to add them to [AllFoldTargets] slice too.
+
+	FoldManagedFields FoldTarget = "managedFields" // metadata.managedFields
+	FoldLastApplied   FoldTarget = "lastApplied"   // the kubectl.kubernetes.io/last-applied-configuration annotation
+	FoldBlobs         FoldTarget = "blobs"         // long base64 strings and PEM certificates/keys
+)
+
+var AllFoldTargets = []FoldTarget{
+	FoldManagedFields,
+	FoldLastApplied,
+	FoldBlobs,
+}
+
+// Fold is a list of parts to hide, parsed from a comma-separated
+// string such as "managedFields,lastApplied". The special value "all"
+// means all of [AllFoldTargets].
+type Fold []FoldTarget
+
+var (
+	_ encoding.TextMarshaler   = Fold{}
+	_ encoding.TextUnmarshaler = &Fold{}
+)
+
+func ParseFold(s string) (Fold, error) {
+	var fold Fold
+	for sub := range strings.SplitSeq(s, ",") {
+		sub = strings.TrimSpace(sub)
+		switch strings.ToLower(sub) {
+		case "", "none":
+			continue
+		case "all":
+			fold = append(fold, AllFoldTargets...)
+			continue
+		}
+		target, err := parseFoldTarget(sub)
+		if err != nil {
+			return nil, err
+		}
+		fold = append(fold, target)
+	}
+	slices.Sort(fold)
+	return slices.Compact(fold), nil
+}
+
+func parseFoldTarget(s string) (FoldTarget, error) {
+	for _, t := range AllFoldTargets {
+		if strings.EqualFold(s, string(t)) {
+			return t, nil // reuse the interned string
+		}
+	}
+	return "", fmt.Errorf("invalid fold target: %q, must be one of: %s", s, joinFoldTargets(AllFoldTargets))
+}
+
+func MustParseFold(s string) Fold {
+	fold, err := ParseFold(s)
+	if err != nil {
+		panic(fmt.Errorf("parse fold: %w", err))
+	}
+	return fold
+}
+
+// Has returns true if the target should be folded.
+func (f Fold) Has(target FoldTarget) bool {
+	return slices.Contains(f, target)
+}
+
+func (f Fold) String() string {
+	if len(f) == 0 {
+		return "none"
+	}
+	return joinFoldTargets(f)
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (f *Fold) UnmarshalText(text []byte) error {
+	fold, err := ParseFold(string(text))
+	if err != nil {
+		return err
+	}
+	*f = fold
+	return nil
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (f Fold) MarshalText() (text []byte, err error) {
+	return []byte(f.String()), nil
+}
+
+func joinFoldTargets(targets []FoldTarget) string {
+	strs := make([]string, len(targets))
+	for i, t := range targets {
+		strs[i] = string(t)
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestParseFold(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Fold
+		wantErr bool
+	}{
+		{
+			name:  "single value",
+			input: "managedFields",
+			want:  Fold{FoldManagedFields},
+		},
+		{
+			name:  "multiple values",
+			input: "managedFields, lastApplied",
+			want:  Fold{FoldLastApplied, FoldManagedFields},
+		},
+		{
+			name:  "case insensitive",
+			input: "MANAGEDFIELDS",
+			want:  Fold{FoldManagedFields},
+		},
+		{
+			name:  "all",
+			input: "all,blobs",
+			want:  Fold{FoldBlobs, FoldLastApplied, FoldManagedFields},
+		},
+		{
+			name:  "none",
+			input: "none",
+			want:  nil,
+		},
+		{
+			name:    "invalid value errors",
+			input:   "managedFields,status",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFold(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil (result %v)", got)
+				}
+				return
+			}
+			testutil.NoError(t, err)
+			testutil.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+// DefaultRedactKeys is the default value of [Redact.Keys].
+var DefaultRedactKeys = []string{
+	"*password*",
+	"*token*",
+	"*_KEY",
+}
+
+// Redact holds the settings for masking secret values in the output,
+// e.g when sharing your terminal during an incident call.
+type Redact struct {
+	Enabled  bool     // Mask secret values, such as Secret data and keys matching any of the keys patterns
+	Contexts []string `jsonschema:"example=prod-*"`                                                    // Always mask secret values when the kubectl context matches any of these glob patterns
+	Keys     []string `jsonschema:"default=*password*,default=*token*,default=*_KEY,example=*secret*"` // Case-insensitive glob patterns of keys whose values are masked, e.g environment variable names
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+// StatusLevel is the kind of a status text in table output,
+// e.g "error" for "CrashLoopBackOff".
+type StatusLevel string
+
+const (
+	StatusLevelError   StatusLevel = "error"   // e.g "Failed", "CrashLoopBackOff"
+	StatusLevelWarning StatusLevel = "warning" // e.g "Pending", "Terminating"
+	StatusLevelSuccess StatusLevel = "success" // e.g "Running", "Completed"
+)
+
+// RowRule applies a style to whole rows in table output, such as "kubectl get pods",
+// to make some rows stand out from the rest. When a rule has both a status and
+// a value, then both must match. Only the first matching rule is applied.
+type RowRule struct {
+	Status StatusLevel `jsonschema:"enum=error,enum=warning,enum=success"`        // Matches rows where any cell has this kind of status, e.g "error" for "CrashLoopBackOff"
+	Column string      `jsonschema:"example=STATUS"`                              // Header name of the column to match the value against, or any column if empty
+	Value  string      `jsonschema:"example=Completed,example=*BackOff"`          // Glob pattern of the cell value to match, e.g "Completed"
+	Style  color.Color `jsonschema:"example=dim,example=bold,example=bg=#3a0000"` // Style added beneath the cell colors of matching rows, e.g "dim" or a background color
+}
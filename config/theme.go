@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config/color"
@@ -29,6 +30,9 @@ func NewBaseTheme(preset Preset) *Theme {
 			Table: ThemeTable{
 				Header: color.MustParse("bold"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("cyan / magenta / blue / yellow / hicyan / himagenta / hiblue / hiyellow"),
+			},
 			Data: ThemeData{
 				String: color.MustParse("hiyellow"),
 			},
@@ -50,6 +54,9 @@ func NewBaseTheme(preset Preset) *Theme {
 			Table: ThemeTable{
 				Header: color.MustParse("bold"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("cyan / magenta / blue / yellow / hiblue / himagenta"),
+			},
 			Data: ThemeData{
 				String: color.MustParse("yellow"),
 			},
@@ -76,6 +83,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("white:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / white / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #f0e442 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	case PresetProtLight:
@@ -98,6 +108,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("black:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / black / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	// Special Preset for Deuteranopia
@@ -121,6 +134,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("white:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / white / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #f0e442 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	case PresetDeutLight:
@@ -143,6 +159,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("black:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / black / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	// Special Preset for Tritanopia
@@ -166,6 +185,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("white:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / white / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #f0e442 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	case PresetTritLight:
@@ -188,6 +210,9 @@ func NewBaseTheme(preset Preset) *Theme {
 				Header:  color.MustParse("black:bold"),
 				Columns: color.MustParseSlice("#2aabee / #6afd6a:bold / #4860e6 / black / #feb927"),
 			},
+			Identity: ThemeIdentity{
+				Palette: color.MustParseSlice("#e69f00 / #56b4e9 / #009e73 / #0072b2 / #d55e00 / #cc79a7"),
+			},
 		}
 
 	// Pre-v0.3.0
@@ -301,27 +326,56 @@ type Theme struct {
 	Table  ThemeTable  // used in table output, e.g "kubectl get" and parts of "kubectl describe"
 	Stderr ThemeStderr // used in kubectl's stderr output
 
+	Identity ThemeIdentity // used on namespaces, nodes, and resource kinds when identity colors are enabled
+
 	// Order here matters. The other groups below commonly refer to "theme.apply.dryrun"
 
 	Apply ThemeApply // used in "kubectl apply"
 
-	Annotate ThemeAnnotate // used in "kubectl annotate"
-	Create   ThemeCreate   // used in "kubectl create"
-	Delete   ThemeDelete   // used in "kubectl delete"
-	Describe ThemeDescribe // used in "kubectl describe"
-	Diff     ThemeDiff     // used in "kubectl diff"
-	Drain    ThemeDrain    // used in "kubectl drain"
-	Explain  ThemeExplain  // used in "kubectl explain"
-	Expose   ThemeExpose   // used in "kubectl expose"
-	Help     ThemeHelp     // used in "kubectl --help"
-	Label    ThemeLabel    // used in "kubectl label"
-	Logs     ThemeLogs     // used in "kubectl logs"
-	Options  ThemeOptions  // used in "kubectl options"
-	Patch    ThemePatch    // used in "kubectl patch"
-	Rollout  ThemeRollout  // used in "kubectl rollout"
-	Scale    ThemeScale    // used in "kubectl scale"
-	Uncordon ThemeUncordon // used in "kubectl uncordon"
-	Version  ThemeVersion  // used in "kubectl version"
+	Annotate    ThemeAnnotate    // used in "kubectl annotate"
+	Auth        ThemeAuth        // used in "kubectl auth"
+	Autoscale   ThemeAutoscale   // used in "kubectl autoscale"
+	Certificate ThemeCertificate // used in "kubectl certificate"
+	Config      ThemeConfig      // used in "kubectl config"
+	Cordon      ThemeCordon      // used in "kubectl cordon"
+	Create      ThemeCreate      // used in "kubectl create"
+	Delete      ThemeDelete      // used in "kubectl delete"
+	Describe    ThemeDescribe    // used in "kubectl describe"
+	Diff        ThemeDiff        // used in "kubectl diff"
+	Drain       ThemeDrain       // used in "kubectl drain"
+	Events      ThemeEvents      // used in "kubectl events" and "kubectl get events"
+	Explain     ThemeExplain     // used in "kubectl explain"
+	Expose      ThemeExpose      // used in "kubectl expose"
+	Help        ThemeHelp        // used in "kubectl --help"
+	Label       ThemeLabel       // used in "kubectl label"
+	Logs        ThemeLogs        // used in "kubectl logs"
+	OC          ThemeOC          // used in the OpenShift CLI "oc" specific subcommands, e.g "oc status"
+	Options     ThemeOptions     // used in "kubectl options"
+	Patch       ThemePatch       // used in "kubectl patch"
+	PortForward ThemePortForward // used in "kubectl port-forward" and "kubectl proxy"
+	Raw         ThemeRaw         // used in "kubectl get --raw"
+	Replace     ThemeReplace     // used in "kubectl replace"
+	Rollout     ThemeRollout     // used in "kubectl rollout"
+	Scale       ThemeScale       // used in "kubectl scale"
+	Set         ThemeSet         // used in "kubectl set"
+	Taint       ThemeTaint       // used in "kubectl taint"
+	Uncordon    ThemeUncordon    // used in "kubectl uncordon"
+	Version     ThemeVersion     // used in "kubectl version"
+	Wait        ThemeWait        // used in "kubectl wait"
+}
+
+// LookupColor returns the color of a theme key, e.g "theme.apply.created".
+// Returns false if there is no color with that key.
+func (t *Theme) LookupColor(key string) (color.Color, bool) {
+	var found color.Color
+	var ok bool
+	walkFields(reflect.ValueOf(t).Elem(), "theme", func(viperKey string, value reflect.Value, _ reflect.StructTag) {
+		if ok || !strings.EqualFold(viperKey, key) {
+			return
+		}
+		found, ok = value.Interface().(color.Color)
+	})
+	return found, ok
 }
 
 func (t *Theme) ComputeCache() {
@@ -364,6 +418,14 @@ type ThemeData struct {
 	Number color.Color `defaultFrom:"theme.base.primary"` // used when the value is a number
 	Null   color.Color `defaultFrom:"theme.base.muted"`   // used when the value is null, nil, or none
 
+	Comment  color.Color `defaultFrom:"theme.base.muted"`     // used on comments and document separators, e.g `# comment` and `---` in YAML
+	Anchor   color.Color `defaultFrom:"theme.base.primary"`   // used on anchors and aliases, e.g `&anchor` and `*anchor` in YAML
+	Tag      color.Color `defaultFrom:"theme.base.muted"`     // used on tags, e.g `!!binary` in YAML
+	Image    color.Color `defaultFrom:"theme.base.secondary"` // used on container images in YAML and JSON, e.g `nginx:1.27`. Images using the "latest" tag or no tag at all use theme.status.warning instead
+	Folded   color.Color `defaultFrom:"theme.base.muted"`     // used on placeholders for values hidden by --kubecolor-fold, e.g `# … (42 lines hidden)`
+	Redacted color.Color `defaultFrom:"theme.base.danger"`    // used on values masked by --kubecolor-redact, e.g Secret data and passwords
+	Decoded  color.Color `defaultFrom:"theme.base.success"`   // used on Secret data decoded by --kubecolor-decode-secrets, e.g `# hunter2`
+
 	Quantity      color.Color `defaultFrom:"theme.data.number"`                                           // used when the value is a quantity, e.g "100m" or "5Gi"
 	Duration      color.Color ``                                                                          // used when the value is a duration, e.g "12m" or "1d12h", and for ages older than every objFreshThreshold
 	DurationFresh color.Slice `defaultFromMany:"theme.base.success,theme.base.warning,theme.base.danger"` // colors used for ages under each objFreshThreshold, paired by position
@@ -389,6 +451,13 @@ type ThemeStatus struct {
 type ThemeTable struct {
 	Header  color.Color `defaultFrom:"theme.base.info"`                          // used on table headers
 	Columns color.Slice `defaultFromMany:"theme.base.info,theme.base.secondary"` // used on table columns when no other coloring applies such as status or duration coloring. The multiple colors are cycled based on column ID, from left to right.
+	Summary color.Color `defaultFrom:"theme.base.muted"`                         // used on the footer printed by --kubecolor-summary, except on the counted statuses and verbs
+}
+
+// ThemeIdentity holds colors for the identity coloring,
+// enabled by --kubecolor-identity-colors.
+type ThemeIdentity struct {
+	Palette color.Slice `defaultFromMany:"theme.base.primary,theme.base.secondary,theme.base.info"` // colors picked from by hashing the name of a namespace, node, or resource kind, so the same name always gets the same color
 }
 
 // ThemeStderr holds generic colors for kubectl's stderr output.
@@ -408,6 +477,14 @@ type ThemeStderr struct {
 // ThemeDescribe holds colors for the "kubectl describe" output.
 type ThemeDescribe struct {
 	Key color.Slice `defaultFrom:"theme.base.key"` // used on keys. The multiple colors are cycled based on indentation.
+
+	Allocation     color.Color `defaultFrom:"theme.base.success"` // used on allocated resources below the thresholds in "kubectl describe node", e.g "650m (10%)"
+	AllocationHigh color.Color `defaultFrom:"theme.base.warning"` // used on allocated resources from the thresholds in "allocation.requests" and "allocation.limits", e.g "3800m (95%)"
+	AllocationOver color.Color `defaultFrom:"theme.base.danger"`  // used on overcommitted resources, e.g "8 (200%)"
+
+	ConditionGood    color.Color `defaultFrom:"theme.base.success"` // used on conditions in a good state, e.g "Ready  True" and "MemoryPressure  False"
+	ConditionBad     color.Color `defaultFrom:"theme.base.danger"`  // used on conditions in a bad state, e.g "Ready  False" and "DiskPressure  True"
+	ConditionUnknown color.Color `defaultFrom:"theme.base.warning"` // used on conditions with an unknown state, e.g "Ready  Unknown"
 }
 
 // ThemeApply holds colors for the "kubectl apply" output.
@@ -460,6 +537,10 @@ type ThemeRollout struct {
 	Paused     color.Color `defaultFrom:"theme.base.primary"`   // used on "deployment.apps/foo paused"
 	Resumed    color.Color `defaultFrom:"theme.base.secondary"` // used on "deployment.apps/foo resumed"
 	Restarted  color.Color `defaultFrom:"theme.base.warning"`   // used on "deployment.apps/foo restarted"
+	RolledOut  color.Color `defaultFrom:"theme.base.success"`   // used on "deployment "foo" successfully rolled out"
+	Waiting    color.Color `defaultFrom:"theme.base.muted"`     // used on "Waiting for deployment "foo" rollout to finish: ..."
+	Failed     color.Color `defaultFrom:"theme.base.danger"`    // used on "error: deployment "foo" exceeded its progress deadline"
+	Progress   color.Color `defaultFrom:"theme.base.muted"`     // used on the unfilled part of the progress bar
 
 	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
 	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
@@ -481,6 +562,57 @@ type ThemeUncordon struct {
 	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
 }
 
+// ThemeCordon holds colors for the "kubectl cordon" output.
+type ThemeCordon struct {
+	Cordoned color.Color `defaultFrom:"theme.base.primary"` // used on "node/my-worker-node-01 cordoned"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
+// ThemeTaint holds colors for the "kubectl taint" output.
+type ThemeTaint struct {
+	Tainted   color.Color `defaultFrom:"theme.base.warning"`   // used on "node/my-worker-node-01 tainted"
+	Untainted color.Color `defaultFrom:"theme.base.secondary"` // used on "node/my-worker-node-01 untainted"
+	Modified  color.Color `defaultFrom:"theme.base.warning"`   // used on "node/my-worker-node-01 modified"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
+// ThemeSet holds colors for the "kubectl set" output.
+type ThemeSet struct {
+	Updated color.Color `defaultFrom:"theme.base.warning"` // used on "deployment.apps/foo image updated"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
+// ThemeAutoscale holds colors for the "kubectl autoscale" output.
+type ThemeAutoscale struct {
+	Autoscaled color.Color `defaultFrom:"theme.base.success"` // used on "horizontalpodautoscaler.autoscaling/foo autoscaled"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.success"` // used when outputs unknown format
+}
+
+// ThemeReplace holds colors for the "kubectl replace" output.
+type ThemeReplace struct {
+	Replaced color.Color `defaultFrom:"theme.base.warning"` // used on "deployment.apps/foo replaced"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
+// ThemeCertificate holds colors for the "kubectl certificate" output.
+type ThemeCertificate struct {
+	Approved color.Color `defaultFrom:"theme.base.success"` // used on "certificatesigningrequest.certificates.k8s.io/foo approved"
+	Denied   color.Color `defaultFrom:"theme.base.danger"`  // used on "certificatesigningrequest.certificates.k8s.io/foo denied"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.info"`    // used when outputs unknown format
+}
+
 // ThemeDrain holds colors for the "kubectl drain" output.
 type ThemeDrain struct {
 	Cordoned    color.Color `defaultFrom:"theme.base.primary"` // used on "node/my-worker-node-01 cordoned"
@@ -492,10 +624,35 @@ type ThemeDrain struct {
 	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
 }
 
+// ThemeEvents holds colors for the "kubectl events" and "kubectl get events" output.
+type ThemeEvents struct {
+	Normal     color.Color `defaultFrom:"theme.status.success"` // used on the TYPE column, e.g `Normal`
+	Warning    color.Color `defaultFrom:"theme.status.warning"` // used on the TYPE column, e.g `Warning`
+	WarningRow color.Color `defaultFrom:"theme.status.warning"` // used on the other columns of Warning events, unless they have more specific coloring
+	Repeated   color.Color `defaultFrom:"theme.base.muted"`     // used on repetition counts, e.g `(x150 over 21h)` in `43s (x150 over 21h)`
+	ObjectKind color.Color `defaultFrom:"theme.base.primary"`   // used on the kind in the OBJECT column, e.g `Pod` in `Pod/nginx`
+}
+
 // ThemeExplain holds colors for the "kubectl explain" output.
 type ThemeExplain struct {
-	Key      color.Slice `defaultFrom:"theme.base.key"`    // used on keys. The multiple colors are cycled based on indentation.
-	Required color.Color `defaultFrom:"theme.base.danger"` // used on the trailing "-required-" string
+	Key        color.Slice `defaultFrom:"theme.base.key"`     // used on keys. The multiple colors are cycled based on indentation.
+	Required   color.Color `defaultFrom:"theme.base.danger"`  // used on the trailing "-required-" string
+	Header     color.Color `defaultFrom:"theme.table.header"` // used on the headings, e.g "KIND:", "VERSION:", and "FIELDS:"
+	Resource   color.Color `defaultFrom:"theme.base.primary"` // used on the values of the "GROUP:", "KIND:", and "VERSION:" headings, and the field name in "FIELD:"
+	Enum       color.Color `defaultFrom:"theme.data.string"`  // used on enum values, e.g "Always" in "enum: Always, IfNotPresent, Never"
+	Deprecated color.Color `defaultFrom:"theme.base.muted"`   // used on deprecated fields, e.g "serviceAccount"
+	Tree       color.Color `defaultFrom:"theme.base.muted"`   // used on the tree guides from --kubecolor-explain-tree, e.g "├─"
+
+	Type ThemeExplainType
+}
+
+// ThemeExplainType holds colors for the field types in the "kubectl explain" output, e.g "<string>".
+type ThemeExplainType struct {
+	String     color.Color `defaultFrom:"theme.data.string"`    // used on "<string>"
+	Number     color.Color `defaultFrom:"theme.data.number"`    // used on "<integer>" and "<number>"
+	Boolean    color.Color `defaultFrom:"theme.data.true"`      // used on "<boolean>"
+	Object     color.Color `defaultFrom:"theme.base.secondary"` // used on objects, e.g "<Object>" and "<ObjectMeta>"
+	Collection color.Color `defaultFrom:"theme.base.info"`      // used on lists and maps, e.g "<[]string>" and "<map[string]string>"
 }
 
 // ThemeDiff holds colors for the "kubectl diff" output.
@@ -513,6 +670,92 @@ type ThemeAnnotate struct {
 	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
 }
 
+// ThemeAuth holds colors for the "kubectl auth" output.
+type ThemeAuth struct {
+	Yes        color.Color `defaultFrom:"theme.base.success"` // used on "yes" in "kubectl auth can-i"
+	No         color.Color `defaultFrom:"theme.base.danger"`  // used on "no" in "kubectl auth can-i"
+	VerbRead   color.Color `defaultFrom:"theme.base.success"` // used on read-only verbs in "kubectl auth can-i --list", e.g "get", "list", and "watch"
+	VerbWrite  color.Color `defaultFrom:"theme.base.warning"` // used on verbs that change resources in "kubectl auth can-i --list", e.g "create", "update", and "patch"
+	VerbDanger color.Color `defaultFrom:"theme.base.danger"`  // used on risky verbs in "kubectl auth can-i --list", e.g "*", "delete", and "escalate"
+	Reconciled color.Color `defaultFrom:"theme.base.success"` // used on "clusterrole.rbac.authorization.k8s.io/foo reconciled"
+
+	DryRun   color.Color `defaultFrom:"theme.apply.dryrun"` // used on "(dry run)" and "(server dry run)"
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
+// ThemeConfig holds colors for the "kubectl config" output.
+type ThemeConfig struct {
+	Current    color.Color `defaultFrom:"theme.base.success"` // used on the "*" of the current context in "kubectl config get-contexts"
+	CurrentRow color.Color `defaultFrom:"theme.table.header"` // used beneath the row of the current context in "kubectl config get-contexts"
+	Context    color.Color `defaultFrom:"theme.base.primary"` // used on the context name in "kubectl config current-context"
+	Protected  color.Color `defaultFrom:"theme.base.danger"`  // used on context names matching the protectedContexts patterns, e.g "prod-*"
+	Switched   color.Color `defaultFrom:"theme.base.success"` // used on "Switched to context "foo"."
+	Created    color.Color `defaultFrom:"theme.base.success"` // used on "Context "foo" created."
+	Modified   color.Color `defaultFrom:"theme.base.warning"` // used on "Context "foo" modified."
+	Renamed    color.Color `defaultFrom:"theme.base.warning"` // used on "Context "foo" renamed to "bar"."
+	Set        color.Color `defaultFrom:"theme.base.warning"` // used on "Property "foo" set." and "Cluster "foo" set."
+	Unset      color.Color `defaultFrom:"theme.base.warning"` // used on "Property "foo" unset."
+	Deleted    color.Color `defaultFrom:"theme.base.danger"`  // used on "deleted context foo from /home/user/.kube/config"
+
+	Fallback color.Color `defaultFrom:"theme.base.info"` // used when outputs unknown format
+}
+
+// ThemePortForward holds colors for the "kubectl port-forward" and "kubectl proxy" output.
+type ThemePortForward struct {
+	Local      color.Color `defaultFrom:"theme.base.primary"`   // used on the local address, e.g "127.0.0.1:8080" in "Forwarding from 127.0.0.1:8080 -> 80"
+	Remote     color.Color `defaultFrom:"theme.base.secondary"` // used on the remote port, e.g "80" in "Forwarding from 127.0.0.1:8080 -> 80"
+	Connection color.Color `defaultFrom:"theme.base.muted"`     // used on "Handling connection for 8080"
+	Error      color.Color `defaultFrom:"theme.base.danger"`    // used on "an error occurred forwarding 8080 -> 80: ..."
+
+	Fallback color.Color `defaultFrom:"theme.default"` // used when outputs unknown format
+}
+
+// ThemeRaw holds colors for the "kubectl get --raw" output,
+// such as Prometheus metrics from "/metrics" and health checks from "/readyz?verbose".
+type ThemeRaw struct {
+	Comment      color.Color `defaultFrom:"theme.base.muted"`     // used on "# HELP" and "# TYPE" lines in metrics
+	Metric       color.Color `defaultFrom:"theme.base.info"`      // used on metric names, e.g "apiserver_request_total"
+	Type         color.Color `defaultFrom:"theme.base.secondary"` // used on metric types, e.g "counter" in "# TYPE apiserver_request_total counter"
+	LabelKey     color.Color `defaultFrom:"theme.base.secondary"` // used on metric label keys, e.g "code" in `{code="200"}`
+	LabelValue   color.Color `defaultFrom:"theme.data.string"`    // used on metric label values, e.g `"200"` in `{code="200"}`
+	Value        color.Color `defaultFrom:"theme.data.number"`    // used on metric values, e.g "42" in `apiserver_request_total{code="200"} 42`
+	HealthOK     color.Color `defaultFrom:"theme.base.success"`   // used on passed health checks, e.g "[+]ping ok" and "readyz check passed"
+	HealthFailed color.Color `defaultFrom:"theme.base.danger"`    // used on failed health checks, e.g "[-]etcd failed: reason withheld"
+
+	Fallback color.Color `defaultFrom:"theme.default"` // used when outputs unknown format
+}
+
+// ThemeOC holds colors for the subcommands that only exist in the
+// OpenShift CLI "oc", used when the kubectl command is set to "oc".
+type ThemeOC struct {
+	Project  color.Color `defaultFrom:"theme.base.primary"`   // used on project names, e.g "foo" in `Now using project "foo" on server "https://api.example.com:6443".`
+	Current  color.Color `defaultFrom:"theme.base.success"`   // used on the current project in "oc projects", e.g "* my-project"
+	URL      color.Color `defaultFrom:"theme.base.secondary"` // used on URLs, e.g the server in "oc project" and the routes in "oc status"
+	User     color.Color `defaultFrom:"theme.base.primary"`   // used on the user name in "oc whoami"
+	Resource color.Color `defaultFrom:"theme.base.info"`      // used on resources in "oc status", e.g "svc/nginx"
+	Tree     color.Color `defaultFrom:"theme.base.muted"`     // used on the tree guides in "oc status", e.g "├─"
+	Header   color.Color `defaultFrom:"theme.table.header"`   // used on the sections in "oc status", e.g "Errors:"
+	Running  color.Color `defaultFrom:"theme.base.warning"`   // used on "running" in "oc status", e.g "deployment #2 running for 5 minutes"
+	Deployed color.Color `defaultFrom:"theme.base.success"`   // used on "deployed" in "oc status", e.g "deployment #1 deployed 2 hours ago"
+	Failed   color.Color `defaultFrom:"theme.base.danger"`    // used on "failed" in "oc status", e.g "deployment #3 failed 1 hour ago"
+	Heading  color.Color `defaultFrom:"theme.base.info"`      // used on "-->" in "oc new-app"
+	Created  color.Color `defaultFrom:"theme.base.success"`   // used on `deployment.apps "foo" created` in "oc new-app"
+	Success  color.Color `defaultFrom:"theme.base.success"`   // used on "--> Success" in "oc new-app"
+	Started  color.Color `defaultFrom:"theme.base.success"`   // used on "build.build.openshift.io/foo-2 started" in "oc start-build"
+	Added    color.Color `defaultFrom:"theme.base.success"`   // used on `clusterrole.rbac.authorization.k8s.io/admin added: "bob"` in "oc adm policy"
+	Removed  color.Color `defaultFrom:"theme.base.warning"`   // used on `clusterrole.rbac.authorization.k8s.io/admin removed: "bob"` in "oc adm policy"
+
+	Fallback color.Color `defaultFrom:"theme.default"` // used when outputs unknown format
+}
+
+// ThemeWait holds colors for the "kubectl wait" output.
+type ThemeWait struct {
+	ConditionMet color.Color `defaultFrom:"theme.base.success"` // used on "pod/foo condition met"
+	TimedOut     color.Color `defaultFrom:"theme.base.danger"`  // used on "timed out waiting for the condition on pods/foo"
+
+	Fallback color.Color `defaultFrom:"theme.base.warning"` // used when outputs unknown format
+}
+
 // ThemeLabel holds colors for the "kubectl label" output.
 type ThemeLabel struct {
 	Labeled    color.Color `defaultFrom:"theme.base.success"` // used when label was added
@@ -530,7 +773,9 @@ type ThemeOptions struct {
 
 // ThemeVersion holds colors for the "kubectl version" output.
 type ThemeVersion struct {
-	Key color.Slice `defaultFrom:"theme.base.key"` // used on the key
+	Key         color.Slice `defaultFrom:"theme.base.key"`     // used on the key
+	SkewWarning color.Color `defaultFrom:"theme.base.warning"` // used on the client and server versions when they are 2 minor versions apart, outside the supported version skew
+	SkewDanger  color.Color `defaultFrom:"theme.base.danger"`  // used on the client and server versions when they are 3 or more minor versions apart, or have different major versions
 }
 
 // ThemeHelp holds colors for the "kubectl --help" output.
@@ -595,8 +840,14 @@ func walkFields(val reflect.Value, viperKey string, visitor func(viperKey string
 func visitorComputeCache(viperKey string, value reflect.Value, _ reflect.StructTag) {
 	switch value := value.Addr().Interface().(type) {
 	case *color.Color:
+		value.Key = viperKey
 		value.ComputeCache()
 	case *color.Slice:
+		// Fields defaulting to another slice share the same array, so copy it before setting the keys
+		*value = slices.Clone(*value)
+		for i := range *value {
+			(*value)[i].Key = fmt.Sprintf("%s.%d", viperKey, i)
+		}
 		value.ComputeCache()
 	default:
 		panic(fmt.Errorf("%s: unsupported field type: %T", viperKey, value))
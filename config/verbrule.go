@@ -0,0 +1,11 @@
+package config
+
+// VerbRule colors a verb in the output of commands that change resources, e.g
+// "tainted" in "node/my-node tainted" from "kubectl taint". The rules from the
+// config file are added to the built-in rules, and override them on the same verb.
+type VerbRule struct {
+	Subcommand string `jsonschema:"example=taint,example=set,example=apply"`                               // Subcommand whose output has the verb, e.g "taint", or empty to match all subcommands that have verb coloring
+	Verb       string `jsonschema:"example=tainted,example=image updated"`                                 // Verb to color, e.g "tainted" in "node/my-node tainted"
+	Color      string `jsonschema:"example=theme.taint.tainted,example=theme.base.warning,example=yellow"` // Theme key to use the color of, e.g "theme.apply.created", or a color, e.g "yellow"
+	Prefix     bool   // Whether the verb is at the start of the line instead of the end, e.g "evicting pod" in "evicting pod my-ns/my-pod"
+}
@@ -124,6 +124,17 @@ func main() {
 		},
 	}
 
+	s.Definitions["fold"] = &jsonschema.Schema{
+		Type:        "string",
+		Title:       "Folded output",
+		Description: "Comma-separated list of noisy parts to hide in YAML and JSON output, or \"all\".",
+		Examples: []any{
+			"managedFields",
+			"managedFields,lastApplied",
+			"all",
+		},
+	}
+
 	b, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
 		log.Fatal(err)
@@ -156,7 +167,7 @@ func castToAnySlice[E any](s []E) []any {
 // types to Schema IDs.
 func Lookup(t reflect.Type) jsonschema.ID {
 	switch t.Name() {
-	case "Color", "Slice", "Preset", "Paging", "Duration", "DurationSlice":
+	case "Color", "Slice", "Preset", "Paging", "Duration", "DurationSlice", "Fold":
 		return jsonschema.ID("#/$defs/" + Namer(t.Name()))
 	default:
 		return ""
@@ -170,6 +181,9 @@ func Namer(s string) string {
 		return "guid"
 	case "Slice":
 		return "colorSlice"
+	case "":
+		// unnamed types, like []string
+		return ""
 	}
 	var sb strings.Builder
 	sb.Grow(len(s))
@@ -7,23 +7,23 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/command"
 	"github.com/kubecolor/kubecolor/config"
 	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/export"
 	"github.com/kubecolor/kubecolor/kubectl"
 	"github.com/kubecolor/kubecolor/printer"
 )
 
 var flags = struct {
-	outputDir    string
-	outputFile   string
-	freezeConfig string
-	preset       string
-	width        int
+	outputDir  string
+	outputFile string
+	palette    string
+	preset     string
+	width      int
 
 	prompt       string
 	promptColor  color.Color
@@ -34,10 +34,10 @@ var flags = struct {
 	keywordColor color.Color
 	flagColor    color.Color
 }{
-	outputDir:    "./docs",
-	freezeConfig: "./docs/freeze-config.json",
-	preset:       "dark",
-	width:        100,
+	outputDir: "./docs",
+	palette:   "dark",
+	preset:    "dark",
+	width:     100,
 
 	prompt:       "❯",
 	promptColor:  color.MustParse("green"),
@@ -64,7 +64,7 @@ Flags:
 
 	flag.StringVar(&flags.outputDir, "output-dir", flags.outputDir, "Default directory to output to")
 	flag.StringVar(&flags.outputFile, "output", flags.outputFile, `Path to output to (default "${-output-dir flag}")/${filename of test file}.svg")`)
-	flag.StringVar(&flags.freezeConfig, "freeze-config", flags.freezeConfig, "Path to the charmbracelet freeze config file")
+	flag.StringVar(&flags.palette, "palette", flags.palette, `Background and basic colors of the output image, either "dark" or "light"`)
 	flag.StringVar(&flags.preset, "preset", flags.preset, "Kubecolor theme preset")
 	flag.IntVar(&flags.width, "width", flags.width, "Terminal width in output image")
 	flag.StringVar(&flags.prompt, "prompt", flags.prompt, "Shell prompt used in output image")
@@ -90,9 +90,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Colors are exported from the marked colors, instead of parsing the ANSI codes
+	marks := &color.Marks{}
+	color.UseMarks(marks)
 	printed, err := parseAndPrintCommand(string(input), &EnvStore{Vars: []EnvVar{
 		{Key: "KUBECOLOR_THEME_PRESET", Value: flags.preset},
 	}})
+	color.UseMarks(nil)
 	if err != nil {
 		slog.Error("Failed to print command via kubecolor", "error", err)
 		os.Exit(1)
@@ -106,34 +110,36 @@ func main() {
 		outputPath = filepath.Join(flags.outputDir, outputFilename)
 	}
 
-	if err := runFreeze(printed, outputPath, flags.freezeConfig); err != nil {
+	if err := writeSVG(printed, marks, outputPath); err != nil {
+		slog.Error("Failed to write SVG", "error", err)
 		os.Exit(1)
 	}
 }
 
-func runFreeze(inputText, outputPath, configPath string) error {
-	cmd := freezeCmd("--language=ansi", "--output="+outputPath, "--config="+configPath)
-	cmd.Stdin = strings.NewReader(inputText)
-	out, err := cmd.CombinedOutput()
-	if err != nil {
-		if len(out) == 0 {
-			out = []byte(err.Error())
-		}
-		slog.Error("Freeze failed", "error", out)
-		return err
+func writeSVG(inputText string, marks *color.Marks, outputPath string) error {
+	var palette export.Palette
+	switch flags.palette {
+	case "dark":
+		palette = export.DarkPalette
+	case "light":
+		palette = export.LightPalette
+	default:
+		return fmt.Errorf(`invalid palette %q, must be "dark" or "light"`, flags.palette)
 	}
-	slog.Info(string(out))
-	return nil
-}
 
-func freezeCmd(args ...string) *exec.Cmd {
-	slog.Debug("Running freeze", "args", args)
-	freezePath, err := exec.LookPath("freeze")
-	if err == nil {
-		return exec.Command(freezePath, args...)
+	var buf bytes.Buffer
+	if err := export.WriteSVG(&buf, inputText, export.Options{
+		Marks:   marks,
+		Palette: palette,
+		Columns: flags.width,
+	}); err != nil {
+		return err
 	}
-
-	return exec.Command("go", append([]string{"run", "github.com/charmbracelet/freeze@latest"}, args...)...)
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	slog.Info("Wrote " + outputPath)
+	return nil
 }
 
 type EnvStore struct {
@@ -266,6 +272,7 @@ func runKubecolorCommand(cmd Command, env *EnvStore) (string, error) {
 		SubcommandInfo:    subcommandInfo,
 		Recursive:         subcommandInfo.Recursive,
 		ObjFreshThreshold: cfg.ObjFreshThreshold,
+		Allocation:        cfg.Allocation,
 		Theme:             &cfg.Theme,
 	}
 	p.Print(strings.NewReader(cmd.Input), &buf)
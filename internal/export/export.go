@@ -0,0 +1,138 @@
+// Package export renders colored kubecolor output as standalone HTML or SVG
+// documents, e.g for pasting into wikis and incident reports, or for the
+// screenshots in the docs.
+package export
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+type Format string
+
+const (
+	// NOTE: When adding formats, remember to add them to [AllFormats] slice too.
+
+	FormatNone Format = ""
+	FormatHTML Format = "html"
+	FormatSVG  Format = "svg"
+)
+
+var (
+	AllFormats = []Format{
+		FormatHTML,
+		FormatSVG,
+	}
+
+	_ encoding.TextMarshaler   = FormatNone
+	_ encoding.TextUnmarshaler = new(Format)
+)
+
+func (f Format) String() string {
+	if f == "" {
+		return "none"
+	}
+	return string(f)
+}
+
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatNone, nil
+	}
+	maybeValidFormat := Format(strings.ToLower(s))
+	for _, f := range AllFormats {
+		if maybeValidFormat == f {
+			return f, nil // reuse the interned string
+		}
+	}
+	return FormatNone, fmt.Errorf("invalid export format: %q", s)
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (f Format) MarshalText() (text []byte, err error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+func (f *Format) UnmarshalText(text []byte) error {
+	newFormat, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = newFormat
+	return nil
+}
+
+type Options struct {
+	// Marks are the colors used in the text, which was rendered
+	// while using them with [color.UseMarks].
+	Marks   *color.Marks
+	Palette Palette
+	// Classes makes the HTML use CSS classes named after the theme keys,
+	// e.g "theme-data-string", instead of inline styles.
+	Classes bool
+	// Title is used as the HTML page title, and as the SVG title.
+	Title string
+	// Columns is the minimum width of the SVG, in number of characters.
+	Columns int
+}
+
+// Write renders the colored text in the given format.
+func Write(w io.Writer, format Format, text string, opts Options) error {
+	switch format {
+	case FormatHTML:
+		return WriteHTML(w, text, opts)
+	case FormatSVG:
+		return WriteSVG(w, text, opts)
+	default:
+		return fmt.Errorf("invalid export format: %q", format)
+	}
+}
+
+// Palette is the colors used for the background, for text without any
+// colors, and for the 16 basic ANSI colors.
+type Palette struct {
+	Background string
+	Foreground string
+	Border     string
+	// ANSI has the colors for black, red, green, yellow, blue, magenta,
+	// cyan, white, and then their "bright" variants in the same order.
+	ANSI [16]string
+}
+
+var (
+	// DarkPalette is based on GitHub's dark theme.
+	DarkPalette = Palette{
+		Background: "#0d1117",
+		Foreground: "#e6edf3",
+		Border:     "#30363d",
+		ANSI: [16]string{
+			"#484f58", "#ff7b72", "#3fb950", "#d29922", "#58a6ff", "#bc8cff", "#39c5cf", "#b1bac4",
+			"#6e7681", "#ffa198", "#56d364", "#e3b341", "#79c0ff", "#d2a8ff", "#56d4dd", "#ffffff",
+		},
+	}
+
+	// LightPalette is based on GitHub's light theme.
+	LightPalette = Palette{
+		Background: "#ffffff",
+		Foreground: "#1f2328",
+		Border:     "#d0d7de",
+		ANSI: [16]string{
+			"#24292f", "#cf222e", "#116329", "#4d2d00", "#0969da", "#8250df", "#1b7c83", "#6e7781",
+			"#57606a", "#a40e26", "#1a7f37", "#633c01", "#218bff", "#a475f9", "#3192aa", "#8c959f",
+		},
+	}
+)
+
+// PaletteForPreset returns the palette with a background matching the preset.
+func PaletteForPreset(preset config.Preset) Palette {
+	if strings.HasSuffix(string(preset), "-light") || preset == config.PresetLight {
+		return LightPalette
+	}
+	return DarkPalette
+}
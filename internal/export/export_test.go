@@ -0,0 +1,114 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+// render renders the text while using marks, same as when exporting.
+func render(t *testing.T, fn func() string) (string, *color.Marks) {
+	t.Helper()
+	marks := &color.Marks{}
+	color.UseMarks(marks)
+	defer color.UseMarks(nil)
+	return fn(), marks
+}
+
+func themeColor(key, s string) color.Color {
+	c := color.MustParse(s)
+	c.Key = key
+	return c
+}
+
+func TestParseLines(t *testing.T) {
+	input, marks := render(t, func() string {
+		return themeColor("theme.table.header", "bold").Render("NAME") + "  " + color.MustParse("#ff0000").Render("foo") + "\n" +
+			color.MustParse("bg=yellow:italic").Render("bar\nbaz") + " \033[31mqux\033[0m\033[2K\n"
+	})
+	got := parseLines(input, marks, DarkPalette)
+	want := [][]span{
+		{
+			{text: "NAME", style: style{bold: true, classes: "theme-table-header"}},
+			{text: "  "},
+			{text: "foo", style: style{fg: "#ff0000", unkeyed: true}},
+		},
+		{
+			{text: "bar", style: style{bg: DarkPalette.ANSI[3], italic: true, unkeyed: true}},
+		},
+		{
+			{text: "baz", style: style{bg: DarkPalette.ANSI[3], italic: true, unkeyed: true}},
+			{text: " qux"},
+		},
+	}
+	testutil.Equal(t, want, got)
+}
+
+func TestWriteHTML(t *testing.T) {
+	var buf bytes.Buffer
+	text, marks := render(t, func() string { return color.MustParse("green").Render("Running") + " <none>\n" })
+	testutil.MustNoError(t, WriteHTML(&buf, text, Options{Marks: marks, Palette: LightPalette}))
+	want := testutil.NewHereDoc(`
+		<!DOCTYPE html>
+		<html>
+		<head>
+		<meta charset="utf-8">
+		<title>kubecolor</title>
+		<style>
+		body { margin: 0; background: #ffffff; }
+		pre.kubecolor { margin: 0; padding: 20px 24px; background: #ffffff; color: #1f2328; font-family: ui-monospace, SFMono-Regular, SF Mono, Menlo, Consolas, Liberation Mono, monospace; font-size: 14px; line-height: 1.2; }
+		</style>
+		</head>
+		<body>
+		<pre class="kubecolor"><span style="color: #116329">Running</span> &lt;none&gt;</pre>
+		</body>
+		</html>
+		`)
+	testutil.Equal(t, want, buf.String())
+}
+
+func TestWriteHTML_classes(t *testing.T) {
+	var buf bytes.Buffer
+	text, marks := render(t, func() string {
+		return themeColor("theme.status.success", "green").Render("Running") + " " +
+			color.MustParse("bold").Render("1/1") + " " + themeColor("theme.data.key.0", "none").Render("<none>") + "\n"
+	})
+	testutil.MustNoError(t, WriteHTML(&buf, text, Options{Marks: marks, Palette: LightPalette, Classes: true}))
+	want := testutil.NewHereDoc(`
+		<!DOCTYPE html>
+		<html>
+		<head>
+		<meta charset="utf-8">
+		<title>kubecolor</title>
+		<style>
+		body { margin: 0; background: #ffffff; }
+		pre.kubecolor { margin: 0; padding: 20px 24px; background: #ffffff; color: #1f2328; font-family: ui-monospace, SFMono-Regular, SF Mono, Menlo, Consolas, Liberation Mono, monospace; font-size: 14px; line-height: 1.2; }
+		pre.kubecolor .theme-status-success { color: #116329; }
+		</style>
+		</head>
+		<body>
+		<pre class="kubecolor"><span class="theme-status-success">Running</span> <span style="font-weight: bold">1/1</span> &lt;none&gt;</pre>
+		</body>
+		</html>
+		`)
+	testutil.Equal(t, want, buf.String())
+}
+
+func TestWriteSVG(t *testing.T) {
+	var buf bytes.Buffer
+	text, marks := render(t, func() string { return color.MustParse("bold:bg=red").Render("ab") + "c\n" })
+	testutil.MustNoError(t, WriteSVG(&buf, text, Options{Marks: marks, Palette: DarkPalette}))
+	want := testutil.NewHereDoc(`
+		<svg xmlns="http://www.w3.org/2000/svg" width="73.2" height="56.8" viewBox="0 0 73.2 56.8">
+		<title>kubecolor</title>
+		<rect x="0.5" y="0.5" width="72.2" height="55.8" rx="8" fill="#0d1117" stroke="#30363d"/>
+		<g font-family="ui-monospace, SFMono-Regular, SF Mono, Menlo, Consolas, Liberation Mono, monospace" font-size="14" fill="#e6edf3" xml:space="preserve">
+		<rect x="24" y="20" width="16.8" height="16.8" fill="#ff7b72"/>
+		<text y="33.44"><tspan x="24" font-weight="bold">ab</tspan><tspan x="40.8">c</tspan></text>
+		</g>
+		</svg>
+		`)
+	testutil.Equal(t, want, buf.String())
+}
@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bufio"
+	"cmp"
+	"html"
+	"io"
+	"strings"
+)
+
+const (
+	fontFamily = "ui-monospace, SFMono-Regular, SF Mono, Menlo, Consolas, Liberation Mono, monospace"
+	fontSize   = 14
+	lineHeight = 1.2
+)
+
+// WriteHTML renders the colored text as a standalone HTML page,
+// using inline styles so it can be copied from the page as-is,
+// or CSS classes named after the theme keys when [Options.Classes] is set.
+func WriteHTML(w io.Writer, text string, opts Options) error {
+	palette := opts.Palette
+	bw := bufio.NewWriter(w)
+	bw.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	bw.WriteString("<title>" + html.EscapeString(cmp.Or(opts.Title, "kubecolor")) + "</title>\n")
+	bw.WriteString("<style>\n")
+	bw.WriteString("body { margin: 0; background: " + palette.Background + "; }\n")
+	bw.WriteString("pre.kubecolor { margin: 0; padding: 20px 24px; ")
+	bw.WriteString("background: " + palette.Background + "; color: " + palette.Foreground + "; ")
+	bw.WriteString("font-family: " + fontFamily + "; font-size: 14px; line-height: 1.2; }\n")
+	lines := parseLines(text, opts.Marks, palette)
+	if opts.Classes && opts.Marks != nil {
+		for _, mark := range opts.Marks.Keyed() {
+			var s style
+			s.applyMark(mark, palette)
+			if css := spanCSS(s, palette); css != "" {
+				bw.WriteString("pre.kubecolor ." + s.classes + " { " + css + "; }\n")
+			}
+		}
+	}
+	bw.WriteString("</style>\n</head>\n<body>\n<pre class=\"kubecolor\">")
+	for i, line := range lines {
+		if i > 0 {
+			bw.WriteByte('\n')
+		}
+		for _, s := range line {
+			if opts.Classes && s.style.classes != "" && !s.style.unkeyed {
+				bw.WriteString("<span class=\"" + s.style.classes + "\">")
+				bw.WriteString(html.EscapeString(s.text))
+				bw.WriteString("</span>")
+				continue
+			}
+			css := spanCSS(s.style, palette)
+			if css == "" {
+				bw.WriteString(html.EscapeString(s.text))
+				continue
+			}
+			bw.WriteString("<span style=\"" + css + "\">")
+			bw.WriteString(html.EscapeString(s.text))
+			bw.WriteString("</span>")
+		}
+	}
+	bw.WriteString("</pre>\n</body>\n</html>\n")
+	return bw.Flush()
+}
+
+func spanCSS(s style, palette Palette) string {
+	var props []string
+	fg, bg := s.colors(palette)
+	if fg != "" {
+		props = append(props, "color: "+fg)
+	}
+	if bg != "" {
+		props = append(props, "background-color: "+bg)
+	}
+	if s.bold {
+		props = append(props, "font-weight: bold")
+	}
+	if s.faint {
+		props = append(props, "opacity: 0.6")
+	}
+	if s.italic {
+		props = append(props, "font-style: italic")
+	}
+	if decoration := textDecoration(s); decoration != "" {
+		props = append(props, "text-decoration: "+decoration)
+	}
+	if s.hidden {
+		props = append(props, "visibility: hidden")
+	}
+	return strings.Join(props, "; ")
+}
+
+func textDecoration(s style) string {
+	var decorations []string
+	if s.underline {
+		decorations = append(decorations, "underline")
+	}
+	if s.strike {
+		decorations = append(decorations, "line-through")
+	}
+	return strings.Join(decorations, " ")
+}
@@ -0,0 +1,8 @@
+package export
+
+import "github.com/kubecolor/kubecolor/testutil"
+
+func init() {
+	testutil.DiffAllowUnexported(span{})
+	testutil.DiffAllowUnexported(style{})
+}
@@ -0,0 +1,215 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	gookit "github.com/gookit/color"
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+type style struct {
+	fg, bg    string // CSS color, or empty for the palette's default
+	bold      bool
+	faint     bool
+	italic    bool
+	underline bool
+	strike    bool
+	reverse   bool
+	hidden    bool
+
+	// classes are the CSS class names of the theme colors used, e.g "theme-data-string"
+	classes string
+	// unkeyed is true when a color not from the theme was used,
+	// which can only be written using inline styles
+	unkeyed bool
+}
+
+type span struct {
+	text  string
+	style style
+}
+
+// applyMark updates the style using the codes of a marked color.
+func (s *style) applyMark(mark color.Mark, palette Palette) {
+	for _, code := range mark.Parsed {
+		s.apply(code, palette)
+	}
+	if mark.Key == "" {
+		s.unkeyed = true
+	} else {
+		s.classes = strings.TrimSpace(s.classes + " " + className(mark.Key))
+	}
+}
+
+// className returns the CSS class name for a theme key,
+// e.g "theme-data-string" for "theme.data.string".
+func className(key string) string {
+	return strings.ReplaceAll(key, ".", "-")
+}
+
+// apply updates the style using a color code, as parsed by [color.Parse].
+// Raw codes, as in "raw(...)", are ignored.
+func (s *style) apply(code color.ColorCode, palette Palette) {
+	switch c := code.(type) {
+	case gookit.Color:
+		s.applyBasic(c, palette)
+	case gookit.Color256:
+		var css string
+		if c[0] < 16 {
+			css = palette.ANSI[c[0]]
+		} else {
+			css = rgbToCSS(c.RGB())
+		}
+		s.setColor(css, c[1] == 1)
+	case gookit.RGBColor:
+		s.setColor(rgbToCSS(c), c[3] == 1)
+	}
+}
+
+func (s *style) applyBasic(c gookit.Color, palette Palette) {
+	switch {
+	case c == gookit.OpReset:
+		*s = style{}
+	case c == gookit.OpBold:
+		s.bold = true
+	case c == gookit.OpFuzzy:
+		s.faint = true
+	case c == gookit.OpItalic:
+		s.italic = true
+	case c == gookit.OpUnderscore:
+		s.underline = true
+	case c == gookit.OpReverse:
+		s.reverse = true
+	case c == gookit.OpConcealed:
+		s.hidden = true
+	case c == gookit.OpStrikethrough:
+		s.strike = true
+	case c == 22:
+		s.bold, s.faint = false, false
+	case c == 23:
+		s.italic = false
+	case c == 24:
+		s.underline = false
+	case c == 27:
+		s.reverse = false
+	case c == 28:
+		s.hidden = false
+	case c == 29:
+		s.strike = false
+	case c >= gookit.FgBlack && c <= gookit.FgWhite:
+		s.fg = palette.ANSI[c-gookit.FgBlack]
+	case c == gookit.FgDefault:
+		s.fg = ""
+	case c >= gookit.BgBlack && c <= gookit.BgWhite:
+		s.bg = palette.ANSI[c-gookit.BgBlack]
+	case c == gookit.BgDefault:
+		s.bg = ""
+	case c >= gookit.FgDarkGray && c <= gookit.FgLightWhite:
+		s.fg = palette.ANSI[8+c-gookit.FgDarkGray]
+	case c >= gookit.BgDarkGray && c <= gookit.BgLightWhite:
+		s.bg = palette.ANSI[8+c-gookit.BgDarkGray]
+	}
+}
+
+func (s *style) setColor(css string, isBg bool) {
+	if isBg {
+		s.bg = css
+	} else {
+		s.fg = css
+	}
+}
+
+// colors returns the foreground and background colors to use,
+// taking [style.reverse] into account.
+func (s style) colors(palette Palette) (fg, bg string) {
+	fg, bg = s.fg, s.bg
+	if s.reverse {
+		fg, bg = bg, fg
+		if fg == "" {
+			fg = palette.Background
+		}
+		if bg == "" {
+			bg = palette.Foreground
+		}
+	}
+	return fg, bg
+}
+
+func rgbToCSS(rgb gookit.RGBColor) string {
+	return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2])
+}
+
+// parseLines splits text colored with markers from marks into lines of
+// styled spans. Styles carry over between lines, same as in a terminal.
+//
+// Any other escape sequences are dropped, such as cursor movement,
+// or color codes already in kubectl's output.
+func parseLines(text string, marks *color.Marks, palette Palette) [][]span {
+	text = strings.TrimSuffix(text, "\n")
+	var (
+		lines   [][]span
+		current style
+	)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		var spans []span
+		for line != "" {
+			start := strings.Index(line, "\033[")
+			if start == -1 {
+				spans = appendSpan(spans, line, current)
+				break
+			}
+			spans = appendSpan(spans, line[:start], current)
+			params, rest, ok := cutCSI(line[start+2:])
+			if !ok {
+				// Incomplete escape sequence. Drop it.
+				break
+			}
+			line = rest
+			if params.final != 'm' {
+				// Not a color, e.g cursor movement. Ignore it.
+				continue
+			}
+			if params.params == "0" || params.params == "" {
+				current = style{}
+				continue
+			}
+			if marks == nil {
+				continue
+			}
+			if mark, ok := marks.Lookup(params.params); ok {
+				current.applyMark(mark, palette)
+			}
+		}
+		lines = append(lines, spans)
+	}
+	return lines
+}
+
+func appendSpan(spans []span, text string, s style) []span {
+	if text == "" {
+		return spans
+	}
+	if len(spans) > 0 && spans[len(spans)-1].style == s {
+		spans[len(spans)-1].text += text
+		return spans
+	}
+	return append(spans, span{text: text, style: s})
+}
+
+type csi struct {
+	params string
+	final  byte
+}
+
+// cutCSI cuts a "Control Sequence Introducer" escape sequence, where s is
+// the text after the "\033[" prefix.
+func cutCSI(s string) (csi, string, bool) {
+	for i := 0; i < len(s); i++ {
+		if b := s[i]; b >= 0x40 && b <= 0x7e {
+			return csi{params: s[:i], final: b}, s[i+1:], true
+		}
+	}
+	return csi{}, "", false
+}
@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	svgPaddingX  = 24
+	svgPaddingY  = 20
+	svgCharWidth = fontSize * 0.6 // width of monospace characters, relative to the font size
+	svgLineSize  = fontSize * lineHeight
+	svgRadius    = 8
+)
+
+// WriteSVG renders the colored text as an SVG image, styled like a
+// terminal window with a background matching the palette.
+func WriteSVG(w io.Writer, text string, opts Options) error {
+	palette := opts.Palette
+	lines := parseLines(text, opts.Marks, palette)
+
+	columns := opts.Columns
+	for _, line := range lines {
+		columns = max(columns, lineLength(line))
+	}
+	width := svgPaddingX*2 + float64(columns)*svgCharWidth
+	height := svgPaddingY*2 + float64(len(lines))*svgLineSize
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		formatFloat(width), formatFloat(height))
+	fmt.Fprintf(bw, "<title>%s</title>\n", html.EscapeString(cmp.Or(opts.Title, "kubecolor")))
+	fmt.Fprintf(bw, `<rect x="0.5" y="0.5" width="%s" height="%s" rx="%d" fill="%s" stroke="%s"/>`+"\n",
+		formatFloat(width-1), formatFloat(height-1), svgRadius, palette.Background, palette.Border)
+	fmt.Fprintf(bw, `<g font-family="%s" font-size="%d" fill="%s" xml:space="preserve">`+"\n",
+		fontFamily, fontSize, palette.Foreground)
+
+	for i, line := range lines {
+		top := svgPaddingY + float64(i)*svgLineSize
+		col := 0
+		for _, s := range line {
+			length := utf8.RuneCountInString(s.text)
+			if _, bg := s.style.colors(palette); bg != "" {
+				fmt.Fprintf(bw, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+					formatFloat(svgPaddingX+float64(col)*svgCharWidth), formatFloat(top),
+					formatFloat(float64(length)*svgCharWidth), formatFloat(svgLineSize), bg)
+			}
+			col += length
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+		// Baseline is placed so the text is vertically centered in the line
+		fmt.Fprintf(bw, `<text y="%s">`, formatFloat(top+svgLineSize*0.8))
+		col = 0
+		for _, s := range line {
+			fmt.Fprintf(bw, `<tspan x="%s"%s>%s</tspan>`,
+				formatFloat(svgPaddingX+float64(col)*svgCharWidth), spanSVGAttrs(s.style, palette), html.EscapeString(s.text))
+			col += utf8.RuneCountInString(s.text)
+		}
+		bw.WriteString("</text>\n")
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+	return bw.Flush()
+}
+
+func spanSVGAttrs(s style, palette Palette) string {
+	var attrs string
+	if fg, _ := s.colors(palette); fg != "" {
+		attrs += fmt.Sprintf(` fill="%s"`, fg)
+	}
+	if s.bold {
+		attrs += ` font-weight="bold"`
+	}
+	if s.faint {
+		attrs += ` fill-opacity="0.6"`
+	}
+	if s.italic {
+		attrs += ` font-style="italic"`
+	}
+	if decoration := textDecoration(s); decoration != "" {
+		attrs += fmt.Sprintf(` text-decoration="%s"`, decoration)
+	}
+	if s.hidden {
+		attrs += ` visibility="hidden"`
+	}
+	return attrs
+}
+
+func lineLength(line []span) int {
+	var length int
+	for _, s := range line {
+		length += utf8.RuneCountInString(s.text)
+	}
+	return length
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
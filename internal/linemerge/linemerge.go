@@ -0,0 +1,319 @@
+// Package linemerge contains an implementation for writing the output of
+// multiple streams (e.g stdout and stderr) in the same order as their input
+// lines arrived in.
+package linemerge
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// DefaultDelay is how long a line is held back, waiting for lines from other
+// streams that arrived before it but are still being processed.
+var DefaultDelay = 100 * time.Millisecond
+
+// Merger writes lines from multiple [Stream] in the order that their input
+// lines were read.
+//
+// Each line is stamped when its input is read by the reader goroutine of
+// the stream. Output lines written by the printers are then held back for
+// [Merger.Delay] before they're flushed, line by line, to their stream's
+// writer in order of those stamps.
+type Merger struct {
+	Delay time.Duration
+
+	seq     atomic.Uint64
+	mu      sync.Mutex
+	pending []pendingLine
+	wake    chan struct{}
+	open    int
+	started bool
+	done    chan struct{}
+}
+
+type pendingLine struct {
+	stamp stamp
+	data  []byte
+	w     io.Writer
+}
+
+type stamp struct {
+	seq uint64
+	at  time.Time
+}
+
+func New() *Merger {
+	return &Merger{
+		Delay: DefaultDelay,
+		wake:  make(chan struct{}, 1),
+		done:  make(chan struct{}),
+	}
+}
+
+// NewStream returns a new stream that reads from r and writes to w.
+//
+// The stream must be closed using [Stream.Close] once the printer has
+// finished writing to it.
+func (m *Merger) NewStream(r io.Reader, w io.Writer) *Stream {
+	m.mu.Lock()
+	m.open++
+	if !m.started {
+		m.started = true
+		go m.run()
+	}
+	m.mu.Unlock()
+
+	s := &Stream{
+		merger: m,
+		w:      w,
+		lines:  make(chan readLine, 100),
+		closed: make(chan struct{}),
+	}
+	go s.readGoroutine(r)
+	return s
+}
+
+// Wait blocks until all streams have been closed and all of their lines
+// have been written.
+func (m *Merger) Wait() {
+	m.mu.Lock()
+	started := m.started
+	m.mu.Unlock()
+	if !started {
+		return
+	}
+	<-m.done
+}
+
+func (m *Merger) add(line pendingLine) {
+	m.mu.Lock()
+	// Keep sorted by sequence number. Lines are mostly added in order,
+	// so searching from the end is usually a no-op.
+	index := len(m.pending)
+	for index > 0 && m.pending[index-1].stamp.seq > line.stamp.seq {
+		index--
+	}
+	m.pending = slices.Insert(m.pending, index, line)
+	m.mu.Unlock()
+	m.notify()
+}
+
+func (m *Merger) closeStream() {
+	m.mu.Lock()
+	m.open--
+	m.mu.Unlock()
+	m.notify()
+}
+
+func (m *Merger) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Merger) run() {
+	defer close(m.done)
+	timer := time.NewTimer(m.Delay)
+	defer timer.Stop()
+	for {
+		nextFlush, finished := m.flush()
+		if finished {
+			return
+		}
+		if nextFlush > 0 {
+			timer.Reset(nextFlush)
+			select {
+			case <-m.wake:
+			case <-timer.C:
+			}
+		} else {
+			<-m.wake
+		}
+	}
+}
+
+// flush writes all lines that have waited long enough, and returns
+// how long until the next pending line is due.
+func (m *Merger) flush() (nextFlush time.Duration, finished bool) {
+	m.mu.Lock()
+	allClosed := m.open == 0
+	now := time.Now()
+	var ready []pendingLine
+	for len(m.pending) > 0 {
+		line := m.pending[0]
+		if !allClosed {
+			if wait := m.Delay - now.Sub(line.stamp.at); wait > 0 {
+				nextFlush = wait
+				break
+			}
+		}
+		ready = append(ready, line)
+		m.pending = m.pending[1:]
+	}
+	m.mu.Unlock()
+
+	for _, line := range ready {
+		line.w.Write(line.data)
+	}
+	return nextFlush, allClosed
+}
+
+// Stream is both the [io.Reader] and [io.Writer] given to a printer.
+// Reading gives the input one line at a time, and writing stamps each
+// output line with the stamp of the last line that was read.
+type Stream struct {
+	merger *Merger
+	w      io.Writer
+
+	lines     chan readLine
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	current  []byte
+	lastRead atomic.Pointer[stamp]
+	readErr  error
+
+	// mu guards buf and tailTimer, as the tail is also flushed by the timer
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	tailTimer *time.Timer
+}
+
+type readLine struct {
+	stamp stamp
+	data  []byte
+	err   error
+}
+
+// ensures it implements the interfaces
+var (
+	_ io.Reader      = &Stream{}
+	_ io.WriteCloser = &Stream{}
+)
+
+func (s *Stream) readGoroutine(r io.Reader) {
+	defer close(s.lines)
+	reader := bufio.NewReader(r)
+	for {
+		b, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			err = nil
+		}
+		if len(b) > 0 {
+			line := readLine{
+				stamp: stamp{seq: s.merger.seq.Add(1), at: time.Now()},
+				data:  bytes.Clone(b),
+			}
+			select {
+			case s.lines <- line:
+			case <-s.closed:
+				return
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				select {
+				case s.lines <- readLine{err: err}:
+				case <-s.closed:
+				}
+			}
+			return
+		}
+	}
+}
+
+// Read implements [io.Reader].
+// It never returns more than one line per call, so that the stamp of
+// the line stays accurate for the output it produces.
+func (s *Stream) Read(b []byte) (int, error) {
+	if len(s.current) == 0 {
+		if s.readErr != nil {
+			return 0, s.readErr
+		}
+		line, ok := <-s.lines
+		if !ok {
+			s.readErr = io.EOF
+			return 0, io.EOF
+		}
+		if line.err != nil {
+			s.readErr = line.err
+			return 0, line.err
+		}
+		s.current = line.data
+		s.lastRead.Store(&line.stamp)
+	}
+	n := copy(b, s.current)
+	s.current = s.current[n:]
+	return n, nil
+}
+
+// Write implements [io.Writer].
+// Complete lines are passed on to the merger right away. A trailing
+// incomplete line is kept until the next write, but is passed on anyway
+// if it's still incomplete after [Merger.Delay], so that output updated
+// in-place, like progress bars that never end with a newline, is still shown.
+func (s *Stream) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buf.Write(b)
+	for {
+		index := bytes.IndexByte(s.buf.Bytes(), '\n')
+		if index == -1 {
+			break
+		}
+		s.emit(bytes.Clone(s.buf.Next(index + 1)))
+	}
+	if s.buf.Len() > bytesutil.MaxLineLength {
+		s.emit(bytes.Clone(s.buf.Next(s.buf.Len())))
+	}
+	if s.buf.Len() > 0 && s.tailTimer == nil {
+		s.tailTimer = time.AfterFunc(s.merger.Delay, s.flushTail)
+	}
+	return len(b), nil
+}
+
+// flushTail passes on the incomplete line, if any.
+func (s *Stream) flushTail() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tailTimer = nil
+	if s.buf.Len() > 0 {
+		s.emit(bytes.Clone(s.buf.Next(s.buf.Len())))
+	}
+}
+
+func (s *Stream) emit(data []byte) {
+	st := stamp{at: time.Now()}
+	if last := s.lastRead.Load(); last != nil {
+		st = *last
+	}
+	s.merger.add(pendingLine{stamp: st, data: data, w: s.w})
+}
+
+// Close flushes any incomplete line and marks the stream as done.
+// It does not close the underlying reader nor writer.
+func (s *Stream) Close() error {
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		if s.tailTimer != nil {
+			s.tailTimer.Stop()
+			s.tailTimer = nil
+		}
+		if s.buf.Len() > 0 {
+			s.emit(bytes.Clone(s.buf.Next(s.buf.Len())))
+		}
+		s.mu.Unlock()
+		close(s.closed)
+		s.merger.closeStream()
+	})
+	return nil
+}
@@ -0,0 +1,138 @@
+package linemerge
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+// syncWriter is used to check the order lines are written across streams.
+type syncWriter struct {
+	mu     *sync.Mutex
+	prefix string
+	out    *bytes.Buffer
+}
+
+func (w *syncWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.out.WriteString(w.prefix)
+	return w.out.Write(b)
+}
+
+func TestMerger_preservesArrivalOrder(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	stdoutW := &syncWriter{mu: &mu, prefix: "out: ", out: &out}
+	stderrW := &syncWriter{mu: &mu, prefix: "err: ", out: &out}
+
+	stdoutR, stdoutPipe := io.Pipe()
+	stderrR, stderrPipe := io.Pipe()
+
+	m := New()
+	m.Delay = 20 * time.Millisecond
+	stdout := m.NewStream(stdoutR, stdoutW)
+	stderr := m.NewStream(stderrR, stderrW)
+
+	var wg sync.WaitGroup
+	// stdout printer is slow, to simulate a printer that takes time to color
+	wg.Go(func() {
+		defer stdout.Close()
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			time.Sleep(5 * time.Millisecond)
+			fmt.Fprintln(stdout, strings.ToUpper(scanner.Text()))
+		}
+	})
+	wg.Go(func() {
+		defer stderr.Close()
+		io.Copy(stderr, stderr)
+	})
+
+	stdoutPipe.Write([]byte("first\n"))
+	time.Sleep(2 * time.Millisecond)
+	stderrPipe.Write([]byte("warning\n"))
+	time.Sleep(2 * time.Millisecond)
+	stdoutPipe.Write([]byte("second\n"))
+	stdoutPipe.Close()
+	stderrPipe.Close()
+
+	wg.Wait()
+	m.Wait()
+
+	want := strings.Join([]string{
+		"out: FIRST",
+		"err: warning",
+		"out: SECOND",
+		"",
+	}, "\n")
+	testutil.Equal(t, want, out.String())
+}
+
+func TestStream_partialLineFlushedOnClose(t *testing.T) {
+	var out bytes.Buffer
+	m := New()
+	s := m.NewStream(strings.NewReader("no newline"), &out)
+	io.Copy(s, s)
+	s.Close()
+	m.Wait()
+
+	testutil.Equal(t, "no newline", out.String())
+}
+
+func TestStream_partialLineFlushedAfterDelay(t *testing.T) {
+	out := make(chan string, 10)
+	m := New()
+	m.Delay = 10 * time.Millisecond
+	r, pw := io.Pipe()
+	s := m.NewStream(r, writerFunc(func(b []byte) (int, error) {
+		out <- string(b)
+		return len(b), nil
+	}))
+
+	// The partial line must be written before the stream is closed
+	s.Write([]byte("no newline"))
+	select {
+	case got := <-out:
+		testutil.Equal(t, "no newline", got)
+	case <-time.After(time.Second):
+		t.Fatal("partial line was not written")
+	}
+
+	pw.Close()
+	s.Close()
+	m.Wait()
+}
+
+func TestStream_readsOneLineAtATime(t *testing.T) {
+	m := New()
+	s := m.NewStream(strings.NewReader("foo\nbar\n"), io.Discard)
+	defer m.Wait()
+	defer s.Close()
+
+	buf := make([]byte, 100)
+	n, err := s.Read(buf)
+	testutil.MustNoError(t, err)
+	testutil.Equal(t, "foo\n", string(buf[:n]))
+
+	n, err = s.Read(buf)
+	testutil.MustNoError(t, err)
+	testutil.Equal(t, "bar\n", string(buf[:n]))
+
+	if _, err = s.Read(buf); err != io.EOF {
+		t.Errorf("Want io.EOF, got %v", err)
+	}
+}
+
+type writerFunc func(b []byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
@@ -1,6 +1,7 @@
 package stringutil
 
 import (
+	"path"
 	"strconv"
 	"strings"
 	"time"
@@ -158,3 +159,17 @@ func Truncate(s string, maxLen int) string {
 	}
 	return s
 }
+
+// MatchesAnyGlob returns true if the name matches any of the
+// glob patterns, e.g "prod-*". An empty name never matches.
+func MatchesAnyGlob(name string, patterns []string) bool {
+	if name == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -317,6 +317,14 @@ func TestCutSurrounding(t *testing.T) {
 	}
 }
 
+func TestMatchesAnyGlob(t *testing.T) {
+	patterns := []string{"prod-*", "staging"}
+	testutil.Equal(t, true, MatchesAnyGlob("prod-eu", patterns), "prod-eu")
+	testutil.Equal(t, true, MatchesAnyGlob("staging", patterns), "staging")
+	testutil.Equal(t, false, MatchesAnyGlob("staging-eu", patterns), "staging-eu")
+	testutil.Equal(t, false, MatchesAnyGlob("", []string{"*"}), "empty name")
+}
+
 func TestTruncate(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -363,3 +371,26 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "empty", input: "", want: 0},
+		{name: "ascii", input: "nginx", want: 5},
+		{name: "accented", input: "café", want: 4},
+		{name: "combining accent", input: "cafe\u0301", want: 4},
+		{name: "CJK", input: "日本語", want: 6},
+		{name: "fullwidth", input: "ＡＢ", want: 4},
+		{name: "emoji", input: "ok 😀", want: 5},
+		{name: "zero width joiner", input: "a\u200db", want: 2},
+		{name: "invalid UTF-8", input: "a\xffb", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.Equal(t, tt.want, Width(tt.input))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package stringutil
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns the number of terminal columns the rune takes up when printed,
+// which is 2 for East Asian wide and fullwidth runes (e.g "日" or "😀"),
+// 0 for combining and zero-width runes (e.g U+0301 and U+200D), and 1 for the rest.
+func RuneWidth(r rune) int {
+	switch {
+	case r < utf8.RuneSelf:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Variation_Selector):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Width returns the number of terminal columns the string takes up when printed.
+// See [RuneWidth].
+func Width(s string) int {
+	var w int
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
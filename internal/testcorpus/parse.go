@@ -14,11 +14,12 @@ type File struct {
 }
 
 type Test struct {
-	Name    string
-	Command string
-	Input   string
-	Output  string
-	Env     []EnvVar
+	Name     string
+	Comments []string // additional "#" lines after the name, e.g from --kubecolor-capture
+	Command  string
+	Input    string
+	Output   string
+	Env      []EnvVar
 }
 
 type EnvVar struct {
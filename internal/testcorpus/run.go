@@ -38,18 +38,12 @@ var (
 )
 
 func ExecuteTest(test Test) error {
-	args := strings.Fields(test.Command)
-	if len(args) == 0 {
-		return fmt.Errorf("missing command")
-	}
-	cmd := args[0]
-	args = args[1:]
-
-	if cmd != "kubectl" {
-		return fmt.Errorf(`command must start with "kubectl", but got %q`, cmd)
+	args, env, err := parseCommand(test)
+	if err != nil {
+		return err
 	}
 
-	gotOutput := printCommand(args, test.Input, test.Env)
+	gotOutput := printCommand(args, test.Input, env)
 	gotOutput = strings.TrimSpace(gotOutput)
 
 	if test.Output != gotOutput {
@@ -124,6 +118,24 @@ func indent(s, indent string) string {
 	return indent + strings.ReplaceAll(s, "\n", "\n"+indent)
 }
 
+// parseCommand returns the arguments of the test command, and the env vars
+// to use when printing it.
+func parseCommand(test Test) ([]string, []EnvVar, error) {
+	args := strings.Fields(test.Command)
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("missing command")
+	}
+	switch args[0] {
+	case "kubectl":
+		return args[1:], test.Env, nil
+	case "oc":
+		// use the OpenShift dialect, same as when running kubecolor with KUBECTL_COMMAND=oc
+		return args[1:], append([]EnvVar{{Key: "KUBECTL_COMMAND", Value: "oc"}}, test.Env...), nil
+	default:
+		return nil, nil, fmt.Errorf(`command must start with "kubectl" or "oc", but got %q`, args[0])
+	}
+}
+
 func printCommand(args []string, input string, env []EnvVar) string {
 	os.Clearenv()
 	for _, e := range env {
@@ -139,34 +151,66 @@ func printCommand(args []string, input string, env []EnvVar) string {
 	}
 	cfg.ForceColor = command.ColorLevelTrueColor
 
-	subcommandInfo := kubectl.InspectSubcommandInfo(args, kubectl.NoopPluginHandler{})
+	subcommandInfo := kubectl.DialectFromCommand(cfg.Kubectl).InspectSubcommandInfo(args, kubectl.NoopPluginHandler{})
 
 	if !subcommandInfo.SupportsColoring() {
 		return input
 	}
 
-	var p printer.Printer = &printer.KubectlOutputColoredPrinter{
-		SubcommandInfo:    subcommandInfo,
-		Recursive:         subcommandInfo.Recursive,
-		ObjFreshThreshold: cfg.ObjFreshThreshold,
-		Theme:             &cfg.Theme,
-		KubecolorVersion:  "dev",
+	outputIsTerminal := false
+	if value, ok := os.LookupEnv("OUTPUT_IS_TERMINAL"); ok {
+		if value != "true" {
+			return fmt.Sprintf(`error: var OUTPUT_IS_TERMINAL can only be set to "true", but instead got: %q`, value)
+		}
+		outputIsTerminal = true
 	}
 
+	redactor := printer.NewRedactor(cfg.Redact, &cfg.Theme)
+	coloredPrinter := newColoredPrinter(cfg.Config, subcommandInfo, redactor, outputIsTerminal)
+	if cfg.Summary {
+		coloredPrinter.Summary = &printer.Summary{Theme: &cfg.Theme}
+	}
+	var p printer.Printer = coloredPrinter
+
 	if value, ok := os.LookupEnv("INPUT_IS_STDERR"); ok {
 		if value != "true" {
 			return fmt.Sprintf(`error: var INPUT_IS_STDERR can only be set to "true", but instead got: %q`, value)
 		}
 		p = &printer.StderrPrinter{
-			Theme: &cfg.Theme,
+			Theme:    &cfg.Theme,
+			Redactor: redactor,
 		}
 	}
 
 	var buf bytes.Buffer
 	p.Print(strings.NewReader(input), &buf)
+	coloredPrinter.Summary.Print(&buf)
 	return buf.String()
 }
 
+// newColoredPrinter returns the printer for the command, configured the same
+// way as when running kubecolor.
+func newColoredPrinter(cfg *config.Config, subcommandInfo *kubectl.SubcommandInfo, redactor *printer.Redactor, outputIsTerminal bool) *printer.KubectlOutputColoredPrinter {
+	return &printer.KubectlOutputColoredPrinter{
+		SubcommandInfo:    subcommandInfo,
+		Recursive:         subcommandInfo.Recursive,
+		ObjFreshThreshold: cfg.ObjFreshThreshold,
+		Allocation:        cfg.Allocation,
+		Theme:             &cfg.Theme,
+		KubecolorVersion:  "dev",
+		Fold:              cfg.Fold,
+		Redactor:          redactor,
+		DecodeSecrets:     cfg.DecodeSecrets,
+		RowRules:          cfg.RowRules,
+		Identity:          printer.NewIdentityColors(cfg.IdentityColors, &cfg.Theme),
+		ExplainTree:       cfg.ExplainTree,
+		Terminal:          outputIsTerminal,
+		ProtectedContexts: cfg.ProtectedContexts,
+		RolloutProgress:   cfg.RolloutProgress && outputIsTerminal,
+		Verbs:             cfg.Verbs,
+	}
+}
+
 func createColoredDiff(path, want, got string) string {
 	edits := myers.ComputeEdits(span.URIFromPath(path), want, got)
 	unified := gotextdiff.ToUnified("want", "got", want, edits)
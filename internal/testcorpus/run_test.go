@@ -1,10 +1,16 @@
 package testcorpus
 
 import (
+	"bytes"
 	"errors"
 	"strings"
 	"testing"
 
+	"github.com/kubecolor/kubecolor/command"
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/kubectl"
+	"github.com/kubecolor/kubecolor/printer"
 	"github.com/kubecolor/kubecolor/testutil"
 )
 
@@ -53,6 +59,59 @@ func TestExecuteTest_error(t *testing.T) {
 	}
 }
 
+func TestExecuteTest_capture(t *testing.T) {
+	v := config.NewDefaultViper()
+	v.Set(config.PresetKey, string(config.PresetDark))
+	cfg, err := config.Unmarshal(v)
+	testutil.MustNoError(t, err)
+	cfg.Kubectl = "oc"
+	cfg.Theme.Data.String = color.MustParse("magenta")
+	cfg.Fold = config.Fold{config.FoldManagedFields}
+	cfg.DecodeSecrets = true
+
+	args := []string{"get", "secret", "foo", "-o", "yaml"}
+	subcommandInfo := kubectl.DialectOC.InspectSubcommandInfo(args, kubectl.NoopPluginHandler{})
+	c := &command.Capture{
+		Args:       args,
+		Config:     cfg,
+		Version:    "dev",
+		ColorLevel: "millions",
+		Printers: &command.Printers{
+			FullColoredPrinter: newColoredPrinter(cfg, subcommandInfo, nil, false),
+			ErrorPrinter:       &printer.StderrPrinter{Theme: &cfg.Theme},
+		},
+	}
+	c.RawStdout.WriteString(testutil.NewHereDoc(`
+		apiVersion: v1
+		data:
+		  password: aHVudGVyMg==
+		kind: Secret
+		metadata:
+		  managedFields:
+		  - manager: kubectl
+		    operation: Update
+		  name: foo
+		`))
+
+	var buf bytes.Buffer
+	testutil.MustNoError(t, c.Write(&buf, nil))
+
+	scanner := NewTestScanner(&buf)
+	var tests int
+	for scanner.Scan() {
+		test := scanner.Test()
+		if !strings.HasPrefix(test.Command, "oc ") {
+			t.Errorf("want oc command, got: %q", test.Command)
+		}
+		if err := ExecuteTest(test); err != nil {
+			t.Errorf("replay captured test:\n%s", err)
+		}
+		tests++
+	}
+	testutil.MustNoError(t, scanner.Err())
+	testutil.Equal(t, 1, tests, "number of tests")
+}
+
 func TestPrintCommand_invalidConfig(t *testing.T) {
 	got := printCommand([]string{}, "", []EnvVar{
 		{Key: "KUBECOLOR_PRESET", Value: "non-existing-preset"},
@@ -92,7 +92,11 @@ func (s *TestScanner) readHeaderLine(line string) error {
 	rest := strings.TrimSpace(trimmed[size:])
 	switch firstChar {
 	case '#':
-		s.test.Name = rest
+		if s.test.Name == "" {
+			s.test.Name = rest
+		} else {
+			s.test.Comments = append(s.test.Comments, rest)
+		}
 		return nil
 	case '$':
 		if s.test.Command != "" {
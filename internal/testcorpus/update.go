@@ -78,21 +78,18 @@ func hashReader(r io.Reader) string {
 }
 
 func writeTest(w io.Writer, test Test) error {
-	args := strings.Fields(test.Command)
-	if len(args) == 0 {
-		return fmt.Errorf("missing command")
-	}
-	cmd := args[0]
-	args = args[1:]
-
-	if cmd != "kubectl" {
-		return fmt.Errorf(`command must start with "kubectl", but got %q`, cmd)
+	args, env, err := parseCommand(test)
+	if err != nil {
+		return err
 	}
 
 	fmt.Fprintln(w, testHeaderSeparator)
-	if test.Name != test.Command {
+	if test.Name != test.Command || len(test.Comments) > 0 {
 		fmt.Fprintf(w, "# %s\n", test.Name)
 	}
+	for _, comment := range test.Comments {
+		fmt.Fprintf(w, "# %s\n", comment)
+	}
 	slices.SortFunc(test.Env, func(a, b EnvVar) int {
 		return cmp.Compare(a.Key, b.Key)
 	})
@@ -106,6 +103,6 @@ func writeTest(w io.Writer, test Test) error {
 	fmt.Fprintln(w)
 	fmt.Fprintln(w, testOutputSeparator)
 	fmt.Fprintln(w)
-	fmt.Fprintln(w, strings.TrimSpace(printCommand(args, test.Input, test.Env)))
+	fmt.Fprintln(w, strings.TrimSpace(printCommand(args, test.Input, env)))
 	return nil
 }
@@ -0,0 +1,60 @@
+package kubectl
+
+import (
+	"strings"
+)
+
+// Dialect is the flavor of kubectl-compatible CLI that kubecolor runs,
+// which decides what subcommands are recognized.
+type Dialect byte
+
+const (
+	DialectKubectl Dialect = iota // kubectl
+	DialectOC                     // OpenShift CLI "oc"
+)
+
+// DialectFromCommand returns the dialect of the configured kubectl command,
+// e.g [DialectOC] for "oc" or "/usr/local/bin/oc".
+func DialectFromCommand(command string) Dialect {
+	// Not using [filepath.Base], so Windows paths are handled on all platforms
+	name := command[strings.LastIndexAny(command, `/\`)+1:]
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	if name == "oc" {
+		return DialectOC
+	}
+	return DialectKubectl
+}
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectOC:
+		return "oc"
+	default:
+		return "kubectl"
+	}
+}
+
+// inspectDialectSubcommand returns the subcommands that only exists in the dialect,
+// e.g "new-app" in "oc new-app".
+func (d Dialect) inspectDialectSubcommand(cmd string) (Subcommand, bool) {
+	if d != DialectOC {
+		return Unknown, false
+	}
+	switch Subcommand(cmd) {
+	case
+		Adm,
+		Login,
+		Logout,
+		NewApp,
+		NewBuild,
+		NewProject,
+		Project,
+		Projects,
+		StartBuild,
+		Status,
+		WhoAmI:
+		return Subcommand(cmd), true
+	default:
+		return Unknown, false
+	}
+}
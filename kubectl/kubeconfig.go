@@ -0,0 +1,71 @@
+package kubectl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentContext returns the name of the kubectl context that the command
+// will use, from the "--context" flag, or else the "current-context" from
+// the kubeconfig files. Returns an empty string if no context was found.
+func CurrentContext(args []string) string {
+	if context, ok := flagValue(args, "--context"); ok {
+		return context
+	}
+	for _, path := range kubeconfigPaths(args) {
+		if context := readCurrentContext(path); context != "" {
+			return context
+		}
+	}
+	return ""
+}
+
+// kubeconfigPaths returns the kubeconfig files in the same order as kubectl
+// merges them, where the first file with a "current-context" wins.
+func kubeconfigPaths(args []string) []string {
+	if path, ok := flagValue(args, "--kubeconfig"); ok {
+		return []string{path}
+	}
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{filepath.Join(homeDir, ".kube", "config")}
+}
+
+func readCurrentContext(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var kubeconfig struct {
+		CurrentContext string `yaml:"current-context"`
+	}
+	if err := yaml.Unmarshal(b, &kubeconfig); err != nil {
+		return ""
+	}
+	return kubeconfig.CurrentContext
+}
+
+// flagValue returns the value of a flag in either of
+// the formats "--flag=value" or "--flag value".
+func flagValue(args []string, flag string) (string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+			return value, true
+		}
+		if arg == flag && i+1 < len(args) {
+			return args[i+1], true
+		}
+	}
+	return "", false
+}
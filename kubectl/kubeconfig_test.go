@@ -0,0 +1,36 @@
+package kubectl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestCurrentContext(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.yaml")
+	prod := filepath.Join(dir, "prod.yaml")
+	testutil.MustNoError(t, os.WriteFile(empty, []byte("apiVersion: v1\nkind: Config\n"), 0o600))
+	testutil.MustNoError(t, os.WriteFile(prod, []byte("apiVersion: v1\ncurrent-context: prod-eu\n"), 0o600))
+
+	testutil.Setenv(t, "KUBECONFIG", empty+string(filepath.ListSeparator)+prod)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "from KUBECONFIG", args: []string{"get", "pods"}, want: "prod-eu"},
+		{name: "context flag", args: []string{"get", "pods", "--context", "dev"}, want: "dev"},
+		{name: "context flag with equals", args: []string{"--context=dev", "get", "pods"}, want: "dev"},
+		{name: "kubeconfig flag", args: []string{"get", "pods", "--kubeconfig=" + empty}, want: ""},
+		{name: "after double dash", args: []string{"exec", "foo", "--", "--context=dev"}, want: "prod-eu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutil.Equal(t, tt.want, CurrentContext(tt.args))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package kubectl
 
 import (
+	"slices"
 	"strings"
 )
 
@@ -12,11 +13,19 @@ type SubcommandInfo struct {
 	Follow          bool   // flag: -f, --follow
 	Help            bool   // flag: -h, --help
 	Recursive       bool   // flag: --recursive
+	Raw             bool   // flag: --raw
 	Client          bool   // flag: --client
 	Interactive     bool   // flag: -i, --interactive
 	EditLastApplied bool   // subcommand: apply edit-last-applied
 	SetLastApplied  bool   // subcommand: apply set-last-applied
 	ViewLastApplied bool   // subcommand: apply view-last-applied
+	RolloutStatus   bool   // subcommand: rollout status
+	AuthCanI        bool   // subcommand: auth can-i
+	AuthWhoAmI      bool   // subcommand: auth whoami
+	ConfigAction    string // subcommand: config <action>, e.g "get-contexts" in "config get-contexts"
+	AdmAction       string // subcommand: adm <action>, e.g "top" in "oc adm top pods"
+	List            bool   // flag: --list
+	Events          bool   // resource: get events
 }
 
 // Output is an enum of different "--output=..." types.
@@ -107,13 +116,36 @@ const (
 
 	// oc (OpenShift CLI) specific subcommands
 	Rsh Subcommand = "rsh"
+
+	// oc (OpenShift CLI) specific subcommands, only recognized in [DialectOC]
+	Adm        Subcommand = "adm"
+	Login      Subcommand = "login"
+	Logout     Subcommand = "logout"
+	NewApp     Subcommand = "new-app"
+	NewBuild   Subcommand = "new-build"
+	NewProject Subcommand = "new-project"
+	Project    Subcommand = "project"
+	Projects   Subcommand = "projects"
+	StartBuild Subcommand = "start-build"
+	Status     Subcommand = "status"
+	WhoAmI     Subcommand = "whoami"
 )
 
+// InspectSubcommand returns the kubectl subcommand of the args, e.g [Get] for "get pods".
 func InspectSubcommand(cmdArgs []string, pluginHandler PluginHandler) (Subcommand, bool) {
+	return DialectKubectl.InspectSubcommand(cmdArgs, pluginHandler)
+}
+
+// InspectSubcommand returns the subcommand of the args in the dialect,
+// e.g [NewApp] for "new-app" in [DialectOC].
+func (d Dialect) InspectSubcommand(cmdArgs []string, pluginHandler PluginHandler) (Subcommand, bool) {
 	if len(cmdArgs) == 0 {
 		return Unknown, false
 	}
 	cmd := cmdArgs[0]
+	if sub, ok := d.inspectDialectSubcommand(cmd); ok {
+		return sub, true
+	}
 	switch Subcommand(cmd) {
 	case
 		APIResources,
@@ -190,10 +222,14 @@ func CollectCommandlineOptions(args []string, info *SubcommandInfo) {
 			info.Watch = true
 		case "-f", "--follow":
 			info.Follow = true
+		case "--raw":
+			info.Raw = value != "false"
 		case "--recursive":
 			info.Recursive = value != "false"
 		case "-i", "--interactive":
 			info.Interactive = true
+		case "--list":
+			info.List = value != "false"
 		case "-h", "--help":
 			info.Help = value != "false"
 		}
@@ -231,7 +267,13 @@ func parseArgFlag(args []string) (flag, value string) {
 	return arg, ""
 }
 
+// InspectSubcommandInfo returns the subcommand and the flags of the kubectl args.
 func InspectSubcommandInfo(args []string, pluginHandler PluginHandler) *SubcommandInfo {
+	return DialectKubectl.InspectSubcommandInfo(args, pluginHandler)
+}
+
+// InspectSubcommandInfo returns the subcommand and the flags of the args in the dialect.
+func (d Dialect) InspectSubcommandInfo(args []string, pluginHandler PluginHandler) *SubcommandInfo {
 	ret := &SubcommandInfo{}
 
 	CollectCommandlineOptions(args, ret)
@@ -242,12 +284,16 @@ func InspectSubcommandInfo(args []string, pluginHandler PluginHandler) *Subcomma
 			break
 		}
 
-		cmd, ok := InspectSubcommand(args[i:], pluginHandler)
+		cmd, ok := d.InspectSubcommand(args[i:], pluginHandler)
 		if !ok {
 			continue
 		}
 
 		ret.Subcommand = cmd
+		if cmd == Get {
+			resource, _ := firstPositionalArg(args[i+1:])
+			ret.Events = isEventsResource(resource)
+		}
 		if cmd == Apply && i+1 < len(args) {
 			switch args[i+1] {
 			case "edit-last-applied":
@@ -258,6 +304,23 @@ func InspectSubcommandInfo(args []string, pluginHandler PluginHandler) *Subcomma
 				ret.ViewLastApplied = true
 			}
 		}
+		if cmd == Auth && i+1 < len(args) {
+			switch args[i+1] {
+			case "can-i":
+				ret.AuthCanI = true
+			case "whoami":
+				ret.AuthWhoAmI = true
+			}
+		}
+		if cmd == Config && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			ret.ConfigAction = args[i+1]
+		}
+		if cmd == Adm && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			ret.AdmAction = args[i+1]
+		}
+		if cmd == Rollout && i+1 < len(args) {
+			ret.RolloutStatus = args[i+1] == "status"
+		}
 		return ret
 	}
 
@@ -268,6 +331,51 @@ func InspectSubcommandInfo(args []string, pluginHandler PluginHandler) *Subcomma
 	return ret
 }
 
+// flagsWithValue are common flags that take their value as a separate
+// argument, e.g "-n my-ns", so the value isn't mistaken for a resource.
+var flagsWithValue = []string{
+	"-n", "--namespace",
+	"-l", "--selector",
+	"-o", "--output",
+	"--context",
+	"--field-selector",
+	"--sort-by",
+}
+
+// firstPositionalArg returns the first argument that isn't a flag,
+// e.g the resource "pods,events" in "kubectl get -n my-ns pods,events".
+func firstPositionalArg(args []string) (string, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			return "", false
+		case slices.Contains(flagsWithValue, arg):
+			i++ // skip the flag's value
+		case strings.HasPrefix(arg, "-"):
+		default:
+			return arg, true
+		}
+	}
+	return "", false
+}
+
+// isEventsResource returns true if the argument refers to events,
+// e.g "events", "ev", "pods,events", or "events.events.k8s.io".
+func isEventsResource(arg string) bool {
+	for resource := range strings.SplitSeq(arg, ",") {
+		resource, _, _ = strings.Cut(resource, "/")
+		switch resource {
+		case "events", "event", "ev":
+			return true
+		}
+		if strings.HasPrefix(resource, "events.") {
+			return true
+		}
+	}
+	return false
+}
+
 func (sci *SubcommandInfo) SupportsPager() bool {
 	if sci.Help || sci.Interactive {
 		return false
@@ -281,7 +389,8 @@ func (sci *SubcommandInfo) SupportsPager() bool {
 		Explain,
 		APIResources,
 		APIVersions,
-		Config:
+		Config,
+		Status:
 		return true
 	}
 	return false
@@ -297,9 +406,7 @@ func (sci *SubcommandInfo) SupportsColoring() bool {
 		Edit,
 		Exec,
 		Plugin,
-		Proxy,
-		Run,
-		Wait:
+		Run:
 		return sci.Help
 
 	case Apply:
@@ -319,7 +426,7 @@ func (sci *SubcommandInfo) SupportsColoring() bool {
 		return false
 
 	// oc (OpenShift CLI) specific subcommands
-	case Rsh:
+	case Rsh, Login:
 		return sci.Help
 
 	// By default, all of our commands supports coloring
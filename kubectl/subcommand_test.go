@@ -52,6 +52,14 @@ func TestInspectSubcommandInfo(t *testing.T) {
 		{"get pod --output jsonpath=...", &SubcommandInfo{Subcommand: Get, Output: OutputOther}},
 		{"get pod --output=jsonpath=...", &SubcommandInfo{Subcommand: Get, Output: OutputOther}},
 
+		{"get events", &SubcommandInfo{Subcommand: Get, Events: true}},
+		{"get ev -A --watch", &SubcommandInfo{Subcommand: Get, Events: true, Watch: true}},
+		{"get -n default pods,events", &SubcommandInfo{Subcommand: Get, Events: true}},
+		{"get events.events.k8s.io", &SubcommandInfo{Subcommand: Get, Events: true}},
+		{"get -n events pods", &SubcommandInfo{Subcommand: Get}},
+		{"get pod events", &SubcommandInfo{Subcommand: Get}},
+		{"events -A", &SubcommandInfo{Subcommand: Events}},
+
 		{"describe pod pod-aaa", &SubcommandInfo{Subcommand: Describe}},
 		{"top pod", &SubcommandInfo{Subcommand: Top}},
 		{"top pods", &SubcommandInfo{Subcommand: Top}},
@@ -76,6 +84,21 @@ func TestInspectSubcommandInfo(t *testing.T) {
 		{"apply view-last-applied deployments.apps/whoami", &SubcommandInfo{Subcommand: Apply, ViewLastApplied: true}},
 		{"apply view-last-applied deployments.apps/whoami -o json", &SubcommandInfo{Subcommand: Apply, ViewLastApplied: true, Output: OutputJSON}},
 
+		{"rollout status deployment/nginx", &SubcommandInfo{Subcommand: Rollout, RolloutStatus: true}},
+		{"rollout restart deployment/nginx", &SubcommandInfo{Subcommand: Rollout}},
+
+		{"auth can-i create pods", &SubcommandInfo{Subcommand: Auth, AuthCanI: true}},
+		{"auth can-i --list -n kube-system", &SubcommandInfo{Subcommand: Auth, AuthCanI: true, List: true}},
+		{"auth whoami", &SubcommandInfo{Subcommand: Auth, AuthWhoAmI: true}},
+		{"auth reconcile -f rbac.yaml", &SubcommandInfo{Subcommand: Auth, Follow: true}},
+
+		{"config get-contexts", &SubcommandInfo{Subcommand: Config, ConfigAction: "get-contexts"}},
+		{"config view --minify -o json", &SubcommandInfo{Subcommand: Config, ConfigAction: "view", Output: OutputJSON}},
+		{"config --kubeconfig=other.yaml", &SubcommandInfo{Subcommand: Config}},
+
+		{"get --raw /metrics", &SubcommandInfo{Subcommand: Get, Raw: true}},
+		{"get --raw=/readyz?verbose", &SubcommandInfo{Subcommand: Get, Raw: true}},
+
 		{"rsh", &SubcommandInfo{Subcommand: Rsh}},
 
 		{"testplugin", &SubcommandInfo{Subcommand: KubectlPlugin}},
@@ -110,6 +133,58 @@ func TestInspectSubcommandInfo(t *testing.T) {
 	}
 }
 
+func TestInspectSubcommandInfo_oc(t *testing.T) {
+	tests := []struct {
+		args     string
+		expected *SubcommandInfo
+	}{
+		{"project", &SubcommandInfo{Subcommand: Project}},
+		{"projects", &SubcommandInfo{Subcommand: Projects}},
+		{"status --suggest", &SubcommandInfo{Subcommand: Status}},
+		{"new-app nodejs~https://github.com/sclorg/nodejs-ex.git", &SubcommandInfo{Subcommand: NewApp}},
+		{"new-project my-project", &SubcommandInfo{Subcommand: NewProject}},
+		{"start-build bc/frontend --follow", &SubcommandInfo{Subcommand: StartBuild, Follow: true}},
+		{"logs -f bc/frontend", &SubcommandInfo{Subcommand: Logs, Follow: true}},
+		{"adm top pods", &SubcommandInfo{Subcommand: Adm, AdmAction: "top"}},
+		{"adm policy add-role-to-user admin bob", &SubcommandInfo{Subcommand: Adm, AdmAction: "policy"}},
+		{"whoami", &SubcommandInfo{Subcommand: WhoAmI}},
+		{"get routes", &SubcommandInfo{Subcommand: Get}},
+		{"rsh my-pod", &SubcommandInfo{Subcommand: Rsh}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.args, func(t *testing.T) {
+			t.Parallel()
+			s := DialectOC.InspectSubcommandInfo(strings.Fields(tc.args), NoopPluginHandler{})
+			testutil.Equal(t, s, tc.expected)
+		})
+	}
+}
+
+func TestInspectSubcommandInfo_ocOnlyInOCDialect(t *testing.T) {
+	s := DialectKubectl.InspectSubcommandInfo([]string{"new-app", "nginx"}, NoopPluginHandler{})
+	testutil.Equal(t, s, &SubcommandInfo{Subcommand: Unknown, Help: true})
+}
+
+func TestDialectFromCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    Dialect
+	}{
+		{"kubectl", DialectKubectl},
+		{"/usr/local/bin/kubectl", DialectKubectl},
+		{"oc", DialectOC},
+		{"/usr/local/bin/oc", DialectOC},
+		{`C:\Program Files\oc.exe`, DialectOC},
+		{"kubectl-1.31", DialectKubectl},
+	}
+	for _, tc := range tests {
+		t.Run(tc.command, func(t *testing.T) {
+			testutil.Equal(t, tc.want, DialectFromCommand(tc.command))
+		})
+	}
+}
+
 func TestParseArgFlag(t *testing.T) {
 	tests := []struct {
 		name      string
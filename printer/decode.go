@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dataPathSecretBase64 matches the base64 encoded values of a Secret,
+// in the format of [dataPath.String]. Values in "stringData" are already
+// plain text, so they're not decoded.
+var dataPathSecretBase64 = regexp.MustCompile(`^(items/\[\]/)?data/[^/]+$`)
+
+// decodeSecretValue returns a readable version of a base64 encoded Secret value,
+// as used by --kubecolor-decode-secrets. Text is returned as-is, or quoted
+// if it spans multiple lines, binary content as "<binary N bytes>",
+// and PEM certificates summarized with their subject and expiry date.
+// Returns false if the value isn't valid base64.
+func decodeSecretValue(value string) (string, bool) {
+	s, ok := decodeSecretText(value)
+	if !ok {
+		return "", false
+	}
+	if s == "" || strings.ContainsAny(s, "\n\r\t") || strings.TrimSpace(s) != s {
+		return strconv.Quote(s), true
+	}
+	return s, true
+}
+
+// decodeSecretText is like [decodeSecretValue], but text is never quoted,
+// for when the caller escapes it on its own.
+func decodeSecretText(value string) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", false
+	}
+	if isPEM(string(b)) {
+		if s, ok := summarizePEM(b); ok {
+			return s, true
+		}
+	}
+	if !isPrintableText(b) {
+		return fmt.Sprintf("<binary %d bytes>", len(b)), true
+	}
+	return string(b), true
+}
+
+// summarizePEM returns a short description of each block in PEM encoded data,
+// e.g "<certificate CN=example.com, expires 2030-01-01>".
+func summarizePEM(b []byte) (string, bool) {
+	var parts []string
+	for {
+		block, rest := pem.Decode(b)
+		if block == nil {
+			break
+		}
+		b = rest
+		if block.Type == "CERTIFICATE" {
+			if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+				parts = append(parts, fmt.Sprintf("<certificate %s, expires %s>",
+					cert.Subject, cert.NotAfter.UTC().Format(time.DateOnly)))
+				continue
+			}
+		}
+		parts = append(parts, "<"+strings.ToLower(block.Type)+">")
+	}
+	return strings.Join(parts, " "), len(parts) > 0
+}
+
+// isPrintableText returns true if the bytes are valid UTF-8 without
+// any control characters, except for newlines and tabs. This also makes sure
+// the decoded value can't contain any terminal escape sequences.
+func isPrintableText(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\r' && r != '\t' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package printer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Paths of the values hidden by --kubecolor-fold, in the format of [dataPath.String].
+var (
+	dataPathManagedFields = regexp.MustCompile(`^(items/\[\]/)?metadata/managedFields$`)
+	dataPathLastApplied   = regexp.MustCompile(`^(items/\[\]/)?metadata/annotations/kubectl\.kubernetes\.io/last-applied-configuration$`)
+)
+
+// blobMinLength is the minimum length of base64 strings to hide
+// with --kubecolor-fold=blobs.
+const blobMinLength = 100
+
+// isBase64Blob returns true for long base64 strings,
+// such as the values in a Secret's data.
+func isBase64Blob(s string) bool {
+	if len(s) < blobMinLength {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '+', c == '/', c == '=', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isPEM returns true for PEM encoded certificates and keys.
+func isPEM(s string) bool {
+	return strings.HasPrefix(strings.TrimSpace(s), "-----BEGIN ")
+}
+
+// foldedYAML returns the placeholder for a hidden value in YAML. It's written
+// as a comment, so the key parses as null instead of getting a made up value.
+func foldedYAML(placeholder string) string {
+	return "# " + placeholder
+}
+
+// foldedJSON returns the placeholder for a hidden value in JSON. JSON doesn't
+// have comments, so it's written as a string to keep the output valid JSON.
+func foldedJSON(placeholder string) string {
+	return `"` + placeholder + `"`
+}
+
+// foldedLines is the placeholder text for hidden values spanning multiple lines.
+func foldedLines(n int) string {
+	if n == 1 {
+		return "… (1 line hidden)"
+	}
+	return fmt.Sprintf("… (%d lines hidden)", n)
+}
+
+// foldedChars is the placeholder text for hidden single-line values.
+func foldedChars(n int) string {
+	if n == 1 {
+		return "… (1 character hidden)"
+	}
+	return fmt.Sprintf("… (%d characters hidden)", n)
+}
@@ -0,0 +1,81 @@
+package printer
+
+import (
+	"hash/fnv"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+// IdentityColors picks a color for names such as namespaces, nodes, and
+// resource kinds by hashing the name, so the same name gets the same color
+// in all outputs, e.g in "kubectl get", "kubectl events", "kubectl logs --prefix",
+// and "kubectl apply". A nil IdentityColors doesn't color anything.
+type IdentityColors struct {
+	Palette color.Slice
+}
+
+// NewIdentityColors returns [IdentityColors] if identity coloring is enabled,
+// or else nil.
+func NewIdentityColors(enabled bool, theme *config.Theme) *IdentityColors {
+	if !enabled || len(theme.Identity.Palette) == 0 {
+		return nil
+	}
+	return &IdentityColors{Palette: theme.Identity.Palette}
+}
+
+// Color returns the palette color of the name.
+// Returns false if there is no color for the name.
+func (c *IdentityColors) Color(name string) (color.Color, bool) {
+	if c == nil || len(c.Palette) == 0 || name == "" || name == "<none>" {
+		return color.Color{}, false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return c.Palette[h.Sum32()%uint32(len(c.Palette))], true
+}
+
+// RenderKindPrefix colors the kind prefix of a resource name, e.g "deployment.apps/"
+// in "deployment.apps/nginx", and leaves the rest of the string as-is.
+// The kind is colored the same regardless of its casing and API group,
+// so "Deployment/nginx" and "deployment.apps/nginx" get the same color.
+//
+// Returns false if the string doesn't start with a kind prefix.
+func (c *IdentityColors) RenderKindPrefix(s string) (string, bool) {
+	if c == nil {
+		return s, false
+	}
+	kind, rest, ok := strings.Cut(s, "/")
+	if !ok || !isKindName(kind) {
+		return s, false
+	}
+	resource, _, _ := strings.Cut(strings.ToLower(kind), ".")
+	kindColor, ok := c.Color(resource)
+	if !ok {
+		return s, false
+	}
+	return kindColor.Render(kind+"/") + rest, true
+}
+
+// isKindName returns true if the string looks like a resource kind,
+// with an optional API group, e.g "Pod" or "deployment.apps".
+func isKindName(s string) bool {
+	if s == "" || !isLetter(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isLetter(s[i]) && !isDigit(s[i]) && s[i] != '.' && s[i] != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
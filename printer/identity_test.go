@@ -0,0 +1,47 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestIdentityColors_RenderKindPrefix(t *testing.T) {
+	identity := &IdentityColors{Palette: color.MustParseSlice("red / green / blue / yellow / magenta / cyan")}
+
+	podColor, ok := identity.Color("pod")
+	testutil.Equal(t, true, ok)
+	deployColor, ok := identity.Color("deployment")
+	testutil.Equal(t, true, ok)
+
+	tests := []struct {
+		name   string
+		input  string
+		want   string
+		wantOK bool
+	}{
+		{name: "lowercase kind", input: "pod/nginx", want: podColor.Render("pod/") + "nginx", wantOK: true},
+		{name: "capitalized kind", input: "Pod/nginx", want: podColor.Render("Pod/") + "nginx", wantOK: true},
+		{name: "kind with group", input: "deployment.apps/nginx created", want: deployColor.Render("deployment.apps/") + "nginx created", wantOK: true},
+		{name: "no kind", input: "nginx created", want: "nginx created", wantOK: false},
+		{name: "not a kind", input: "10.0.0.1/24", want: "10.0.0.1/24", wantOK: false},
+		{name: "quoted name", input: `pod "nginx/x" deleted`, want: `pod "nginx/x" deleted`, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := identity.RenderKindPrefix(tt.input)
+			testutil.Equal(t, tt.want, got)
+			testutil.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestIdentityColors_nil(t *testing.T) {
+	var identity *IdentityColors
+	_, ok := identity.Color("default")
+	testutil.Equal(t, false, ok)
+	got, ok := identity.RenderKindPrefix("pod/nginx")
+	testutil.Equal(t, "pod/nginx", got)
+	testutil.Equal(t, false, ok)
+}
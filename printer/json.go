@@ -2,106 +2,490 @@ package printer
 
 import (
 	"bufio"
-	"fmt"
+	"bytes"
+	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
+	"strconv"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
-	"github.com/kubecolor/kubecolor/internal/bytesutil"
-	"github.com/kubecolor/kubecolor/internal/stringutil"
+	"github.com/kubecolor/kubecolor/config/color"
 )
 
+// JSONPrinter colors JSON by tokenizing it, so it does not rely on how
+// the JSON is formatted. This works for kubectl's pretty-printed JSON,
+// but also for minified JSON, other indentations, and multiple
+// concatenated JSON documents, like from "kubectl get -w -o json".
+//
+// Invalid JSON is printed as-is, but with colors on a best-effort basis.
 type JSONPrinter struct {
-	Theme *config.Theme
+	Theme    *config.Theme
+	Fold     config.Fold
+	Redactor *Redactor
+	// DecodeSecrets replaces each base64 encoded value in a Secret's data
+	// with the decoded value. JSON doesn't have comments, so unlike in YAML
+	// it can't be shown beside the encoded value.
+	DecodeSecrets bool
 }
 
 func (p *JSONPrinter) Print(r io.Reader, w io.Writer) {
-	scanner := bufio.NewScanner(r)
-	scanner.Buffer(nil, bytesutil.MaxLineLength)
-	for scanner.Scan() {
-		line := scanner.Text()
-		p.printLineAsJsonFormat(line, w)
+	t := jsonTokenizer{
+		r:        bufio.NewReader(r),
+		w:        bufio.NewWriter(w),
+		theme:    p.Theme,
+		fold:     p.Fold,
+		redactor: p.Redactor,
+
+		decodeSecrets: p.DecodeSecrets,
 	}
-	if err := scanner.Err(); err != nil {
+	if err := t.run(); err != nil {
 		slog.Error("Failed to print JSON output.", "error", err)
 	}
 }
 
-func (p *JSONPrinter) printLineAsJsonFormat(line string, w io.Writer) {
-	indentLen := findIndent(line)
-	indent := line[:indentLen]
-	trimmedLine := line[indentLen:]
-
-	if strings.HasPrefix(trimmedLine, "{") ||
-		strings.HasPrefix(trimmedLine, "}") ||
-		strings.HasPrefix(trimmedLine, "]") {
-		// when coming here, it must not be starting with key.
-		// that patterns are:
-		// {
-		// }
-		// },
-		// ]
-		// ],
-		// note: it must not be "[" because it will be always after key
-		// in this case, just write it without color
-		// fmt.Fprintf(w, "%s", toSpaces(indentCnt))
-		fmt.Fprintf(w, "%s%s", indent, trimmedLine)
-		fmt.Fprintf(w, "\n")
-		return
+type jsonTokenizer struct {
+	r        *bufio.Reader
+	w        *bufio.Writer
+	theme    *config.Theme
+	fold     config.Fold
+	redactor *Redactor
+
+	decodeSecrets bool
+
+	// stack of the currently open objects '{' and arrays '['
+	stack []byte
+	// expectKey is true when the next string is an object key
+	expectKey bool
+	// keys holds the current key of each object in the stack,
+	// used to color some values based on where in a Kubernetes object they are.
+	keys []string
+
+	// replay is read before the reader, used to print a buffered
+	// "status.conditions[]" object once its "type" is known.
+	replay []byte
+	// conditionType is the "type" of the condition currently being printed.
+	conditionType  string
+	conditionDepth int
+
+	// objectKind is the "kind" of the current Kubernetes object, if known,
+	// used to only mask or decode Secret data.
+	objectKind      string
+	objectKindDepth int
+	// envName is the "name" of the current environment variable in a container spec.
+	envName string
+}
+
+// jsonDelimiters are the bytes that ends an unquoted literal
+const jsonDelimiters = "{}[],:\" \t\r\n"
+
+func (t *jsonTokenizer) run() error {
+	defer t.w.Flush()
+	for {
+		b, err := t.readByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		switch b {
+		case '{', '[':
+			t.stack = append(t.stack, b)
+			t.keys = append(t.keys, "")
+			t.expectKey = b == '{'
+			t.w.WriteByte(b)
+			if b == '{' && t.conditionType == "" && dataPathConditionItem.MatchString(t.parentPath()) {
+				if err := t.bufferCondition(); err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+			}
+		case '}', ']':
+			if len(t.stack) > 0 {
+				t.stack = t.stack[:len(t.stack)-1]
+				t.keys = t.keys[:len(t.keys)-1]
+			}
+			if len(t.stack) < t.conditionDepth {
+				t.conditionType = ""
+				t.conditionDepth = 0
+			}
+			if len(t.stack) < t.objectKindDepth {
+				t.objectKind = ""
+				t.objectKindDepth = 0
+			}
+			t.expectKey = false
+			t.w.WriteByte(b)
+		case ',':
+			t.expectKey = t.inObject()
+			t.w.WriteByte(b)
+		case ':':
+			t.expectKey = false
+			t.w.WriteByte(b)
+			if (t.redactor != nil || t.decodeSecrets) && t.objectKind == "" && dataPathSecretData.MatchString(t.path()) {
+				if err := t.bufferSecretData(); err != nil && !errors.Is(err, io.EOF) {
+					return err
+				}
+			}
+			if err := t.foldValue(); err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+		case ' ', '\t', '\r', '\n':
+			t.w.WriteByte(b)
+		case '"':
+			s, err := t.readString()
+			isKey := t.expectKey && t.inObject()
+			if isKey || err != nil || !t.writeDecodedSecret(s) {
+				t.writeToken(s)
+			}
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+		default:
+			s, err := t.readLiteral(b)
+			t.writeToken(s)
+			if err != nil && !errors.Is(err, io.EOF) {
+				return err
+			}
+		}
+	}
+}
+
+// readByte reads the next byte, but flushes the output first if it
+// would otherwise block, so streamed output is printed right away.
+func (t *jsonTokenizer) readByte() (byte, error) {
+	if len(t.replay) > 0 {
+		b := t.replay[0]
+		t.replay = t.replay[1:]
+		return b, nil
+	}
+	if t.r.Buffered() == 0 {
+		if err := t.w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return t.r.ReadByte()
+}
+
+// peekByte is like [jsonTokenizer.readByte], but without advancing the reader.
+func (t *jsonTokenizer) peekByte() (byte, error) {
+	if len(t.replay) > 0 {
+		return t.replay[0], nil
+	}
+	if t.r.Buffered() == 0 {
+		if err := t.w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	b, err := t.r.Peek(1)
+	if err != nil {
+		return 0, err
 	}
+	return b[0], nil
+}
 
-	// when coming here, the line must be one of:
-	// "key": {
-	// "key": [
-	// "key": value
-	// "key": value,
-	// value,
-	// value
+// readString reads a double-quoted string, where the opening quote has
+// already been read. The result includes both quotes.
+// It also stops at newlines, as those are not allowed inside JSON strings,
+// so broken JSON doesn't color the rest of the output as a string.
+func (t *jsonTokenizer) readString() (string, error) {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	escaped := false
+	for {
+		if b, err := t.peekByte(); err == nil && b == '\n' {
+			return sb.String(), nil
+		}
+		b, err := t.readByte()
+		if err != nil {
+			return sb.String(), err
+		}
+		sb.WriteByte(b)
+		switch {
+		case escaped:
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			return sb.String(), nil
+		}
+	}
+}
+
+// readLiteral reads an unquoted value, such as a number, true, false, or null.
+func (t *jsonTokenizer) readLiteral(first byte) (string, error) {
+	var sb strings.Builder
+	sb.WriteByte(first)
+	for {
+		b, err := t.peekByte()
+		if err != nil {
+			return sb.String(), err
+		}
+		if strings.IndexByte(jsonDelimiters, b) != -1 {
+			return sb.String(), nil
+		}
+		t.readByte()
+		sb.WriteByte(b)
+	}
+}
+
+// bufferCondition reads the rest of a condition object, where the
+// opening brace has already been read, and then queues it up to be
+// printed again once its "type" is known, as kubectl sorts "status" before "type".
+func (t *jsonTokenizer) bufferCondition() error {
+	raw, err := t.readContainer('{')
+	var condition struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(raw, &condition) != nil || condition.Type == "" {
+		// Prevents buffering the same object again
+		condition.Type = "<unknown>"
+	}
+	t.conditionType = condition.Type
+	t.conditionDepth = len(t.stack)
+	t.replay = append(raw[1:], t.replay...)
+	return err
+}
 
-	if key, val, ok := strings.Cut(trimmedLine, ": "); ok {
-		fmt.Fprintf(w, "%s%s: %s\n", indent, p.colorizeJSONKey(key, indentLen, 4), p.colorizeJSONValue(val))
+func (t *jsonTokenizer) writeToken(token string) {
+	c := t.valueColor(token)
+	if t.expectKey && t.inObject() {
+		c = ColorDataKey(len(t.stack), 1, t.theme.Data.Key)
+		t.keys[len(t.keys)-1] = unquoteJSONKey(token)
+	} else if t.redactValue(token) {
+		t.w.WriteString(`"` + t.redactor.Render() + `"`)
 		return
 	}
 
-	// when coming here, it will be a value in an array
-	fmt.Fprintf(w, "%s%s\n", indent, p.colorizeJSONValue(trimmedLine))
+	inner, hasQuotes := strings.CutPrefix(token, `"`)
+	if !hasQuotes {
+		t.w.WriteString(c.Render(token))
+		return
+	}
+	inner, hasEndQuote := strings.CutSuffix(inner, `"`)
+	t.w.WriteByte('"')
+	if inner != "" {
+		t.w.WriteString(c.Render(inner))
+	}
+	if hasEndQuote {
+		t.w.WriteByte('"')
+	}
 }
 
-// colorizeJSONKey returns colored json key
-func (p *JSONPrinter) colorizeJSONKey(key string, indentCnt, basicWidth int) string {
-	key, isDoubleQuoted := stringutil.CutSurrounding(key, '"')
-	color := ColorDataKey(indentCnt, basicWidth, p.Theme.Data.Key)
+// readContainer reads the rest of an object or array, where the opening
+// brace or bracket has already been read. The result includes both.
+func (t *jsonTokenizer) readContainer(open byte) ([]byte, error) {
+	raw, err := t.readUntilClose()
+	return append([]byte{open}, raw...), err
+}
 
-	switch {
-	case isDoubleQuoted:
-		return fmt.Sprintf(`"%s"`, color.Render(key))
-	default:
-		return color.Render(key)
+// readUntilClose reads until the end of the current object or array,
+// including the closing brace or bracket.
+func (t *jsonTokenizer) readUntilClose() ([]byte, error) {
+	var raw []byte
+	depth := 1
+	inString, escaped := false, false
+	for depth > 0 {
+		b, err := t.readByte()
+		if err != nil {
+			return raw, err
+		}
+		raw = append(raw, b)
+		switch {
+		case escaped:
+			escaped = false
+		case inString && b == '\\':
+			escaped = true
+		case b == '"':
+			inString = !inString
+		case inString:
+		case b == '{' || b == '[':
+			depth++
+		case b == '}' || b == ']':
+			depth--
+		}
+	}
+	return raw, nil
+}
+
+// bufferSecretData reads the rest of the object after its "data" or
+// "stringData" key, to find out if it's a Secret, and then queues it up to be
+// printed again, as kubectl sorts "data" before "kind".
+func (t *jsonTokenizer) bufferSecretData() error {
+	raw, err := t.readUntilClose()
+	var object struct {
+		Kind string `json:"kind"`
+	}
+	if json.Unmarshal(append([]byte(`{"data":`), raw...), &object) != nil || object.Kind == "" {
+		// Prevents buffering the same object again
+		object.Kind = "<unknown>"
 	}
+	t.objectKind = object.Kind
+	t.objectKindDepth = len(t.stack)
+	t.replay = append(raw, t.replay...)
+	return err
 }
 
-// colorizeJSONValue returns colored json value.
-// This function checks it trailing comma and double quotation exist
-// then colorize the given value considering them.
-func (p *JSONPrinter) colorizeJSONValue(value string) string {
-	switch value {
-	case "{", "[", "{},", "{}":
-		return value
+// foldValue hides the value after "key:" if it matches [JSONPrinter.Fold].
+func (t *jsonTokenizer) foldValue() error {
+	if len(t.fold) == 0 {
+		return nil
+	}
+	path := t.path()
+	foldManagedFields := t.fold.Has(config.FoldManagedFields) && dataPathManagedFields.MatchString(path)
+	foldLastApplied := t.fold.Has(config.FoldLastApplied) && dataPathLastApplied.MatchString(path)
+	foldBlobs := t.fold.Has(config.FoldBlobs) && !t.decodesSecret()
+	if !foldManagedFields && !foldLastApplied && !foldBlobs {
+		return nil
 	}
 
-	value, hasComma := strings.CutSuffix(value, ",")
-	unquotedValue, isDoubleQuoted := stringutil.CutSurrounding(value, '"')
-	color := ColorDataValue(value, p.Theme)
+	for {
+		b, err := t.peekByte()
+		if err != nil {
+			return err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			break
+		}
+		t.readByte()
+		t.w.WriteByte(b)
+	}
 
+	var raw []byte
+	var placeholder string
+	b, err := t.readByte()
+	if err != nil {
+		return err
+	}
 	switch {
-	case hasComma && isDoubleQuoted:
-		return fmt.Sprintf(`"%s",`, color.Render(unquotedValue))
-	case hasComma:
-		return fmt.Sprintf(`%s,`, color.Render(value))
-	case isDoubleQuoted:
-		return fmt.Sprintf(`"%s"`, color.Render(unquotedValue))
+	case (b == '{' || b == '[') && foldManagedFields:
+		raw, err = t.readContainer(b)
+		if err == nil {
+			placeholder = foldedLines(bytes.Count(raw, []byte{'\n'}) + 1)
+		}
+	case b == '"' && (foldLastApplied || foldBlobs):
+		var s string
+		s, err = t.readString()
+		raw = []byte(s)
+		value := unquoteJSONKey(s)
+		switch {
+		case err != nil:
+		case foldLastApplied:
+			placeholder = foldedChars(len(value))
+		case foldBlobs && isPEM(value):
+			placeholder = foldedLines(strings.Count(strings.TrimSpace(value), "\n") + 1)
+		case foldBlobs && isBase64Blob(value):
+			placeholder = foldedChars(len(value))
+		}
 	default:
-		return color.Render(value)
+		raw = []byte{b}
+	}
+	if placeholder == "" {
+		// Not folded, so print it as usual
+		t.replay = append(raw, t.replay...)
+		return err
+	}
+	t.w.WriteString(t.theme.Data.Folded.Render(foldedJSON(placeholder)))
+	return nil
+}
+
+// redactValue returns true if the value should be masked by the redactor.
+// It also keeps track of the object kind and environment variable names.
+func (t *jsonTokenizer) redactValue(token string) bool {
+	if (t.redactor == nil && !t.decodeSecrets) || len(t.stack) == 0 {
+		return false
+	}
+	path := t.path()
+	value := unquoteJSONKey(token)
+	switch {
+	case dataPathKind.MatchString(path):
+		t.objectKind = value
+		t.objectKindDepth = len(t.stack)
+	case dataPathEnvName.MatchString(path):
+		t.envName = value
+	}
+	if t.redactor == nil || !strings.HasPrefix(token, `"`) || !isRedactable(value) {
+		return false
+	}
+	return t.redactor.matchDataValue(path, t.keys[len(t.keys)-1], t.objectKind, t.envName)
+}
+
+// decodesSecret returns true if the value of the current key
+// is decoded by [JSONPrinter.DecodeSecrets]. Masking by the redactor
+// takes precedence over decoding.
+func (t *jsonTokenizer) decodesSecret() bool {
+	return t.decodeSecrets && t.redactor == nil && t.objectKind == "Secret" && dataPathSecretBase64.MatchString(t.path())
+}
+
+// writeDecodedSecret writes the decoded value as a JSON string in place
+// of a base64 encoded Secret value, if enabled by [JSONPrinter.DecodeSecrets].
+// Returns false if nothing was written.
+func (t *jsonTokenizer) writeDecodedSecret(token string) bool {
+	if !t.decodesSecret() {
+		return false
 	}
+	decoded, ok := decodeSecretText(unquoteJSONKey(token))
+	if !ok {
+		return false
+	}
+	quoted := quoteJSONString(decoded)
+	t.w.WriteString(`"` + t.theme.Data.Decoded.Render(quoted[1:len(quoted)-1]) + `"`)
+	return true
+}
+
+// valueColor returns the color for a value,
+// taking the path to the value into account.
+func (t *jsonTokenizer) valueColor(token string) color.Color {
+	if c, ok := colorKubernetesDataValue(t.path(), unquoteJSONKey(token), t.conditionType, t.theme); ok {
+		return c
+	}
+	return ColorDataValue(token, t.theme)
+}
+
+// path returns the path to the current value, e.g "status/conditions/[]/status".
+func (t *jsonTokenizer) path() string {
+	var sb strings.Builder
+	for i, c := range t.stack {
+		if i > 0 {
+			sb.WriteByte('/')
+		}
+		if c == '[' {
+			sb.WriteString(dataPathListItem)
+		} else {
+			sb.WriteString(t.keys[i])
+		}
+	}
+	return sb.String()
+}
+
+// parentPath is like [jsonTokenizer.path], but excluding the innermost object or array.
+func (t *jsonTokenizer) parentPath() string {
+	path := t.path()
+	if i := strings.LastIndexByte(path, '/'); i != -1 {
+		return path[:i]
+	}
+	return ""
+}
+
+// quoteJSONString returns s as a JSON string, without escaping HTML
+// characters like [json.Marshal] does.
+func quoteJSONString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return strconv.Quote(s)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func unquoteJSONKey(token string) string {
+	if s, err := strconv.Unquote(token); err == nil {
+		return s
+	}
+	return strings.Trim(token, `"`)
+}
+
+func (t *jsonTokenizer) inObject() bool {
+	return len(t.stack) > 0 && t.stack[len(t.stack)-1] == '{'
 }
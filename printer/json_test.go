@@ -2,9 +2,13 @@ package printer
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/kubecolor/kubecolor/config"
 	"github.com/kubecolor/kubecolor/config/testconfig"
 	"github.com/kubecolor/kubecolor/testutil"
 )
@@ -20,3 +24,96 @@ func TestJSONPrinter_fail(t *testing.T) {
 	testutil.Equal(t, "", outBuf.String(), "output")
 	testutil.Equal(t, "level=ERROR msg=\"Failed to print JSON output.\" error=test\n", logBuf.String(), "logs")
 }
+
+func TestJSONPrinter_streaming(t *testing.T) {
+	pr, pw := io.Pipe()
+	out := make(chan string)
+	go func() {
+		printer := JSONPrinter{Theme: testconfig.NullTheme}
+		printer.Print(pr, writerFunc(func(b []byte) (int, error) {
+			out <- string(b)
+			return len(b), nil
+		}))
+		close(out)
+	}()
+
+	// The first object must be printed before the second one is written
+	go pw.Write([]byte("{\"a\": 1}\n"))
+	testutil.Equal(t, "{\"a\": 1}\n", <-out, "first object")
+
+	go func() {
+		pw.Write([]byte("{\"b\": 2}\n"))
+		pw.Close()
+	}()
+	testutil.Equal(t, "{\"b\": 2}\n", <-out, "second object")
+}
+
+func TestJSONPrinter_foldRoundTrip(t *testing.T) {
+	input := testutil.NewHereDoc(`
+		{
+		    "metadata": {
+		        "annotations": {
+		            "kubectl.kubernetes.io/last-applied-configuration": "{\"apiVersion\":\"v1\"}"
+		        },
+		        "managedFields": [
+		            {
+		                "manager": "kubectl"
+		            }
+		        ],
+		        "name": "foo"
+		    }
+		}
+		`)
+
+	var outBuf bytes.Buffer
+	printer := JSONPrinter{Theme: testconfig.NullTheme, Fold: config.Fold(config.AllFoldTargets)}
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	var got map[string]any
+	testutil.MustNoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	want := map[string]any{
+		"metadata": map[string]any{
+			"annotations":   map[string]any{"kubectl.kubernetes.io/last-applied-configuration": "… (19 characters hidden)"},
+			"managedFields": "… (5 lines hidden)",
+			"name":          "foo",
+		},
+	}
+	testutil.Equal(t, want, got)
+}
+
+func TestJSONPrinter_decodeSecretsValidJSON(t *testing.T) {
+	input := testutil.NewHereDoc(`
+		{
+		    "data": {
+		        "comment": "ZW5kICovIGhlcmUgIiBxdW90ZQ==",
+		        "config.ini": "dXNlcj1hZG1pbgpwb3J0PTU0MzIK",
+		        "keystore.bin": "AAEC//4=",
+		        "password": "aHVudGVyMg=="
+		    },
+		    "kind": "Secret"
+		}
+		`)
+
+	var outBuf bytes.Buffer
+	printer := JSONPrinter{Theme: testconfig.NullTheme, DecodeSecrets: true}
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	var got map[string]any
+	testutil.MustNoError(t, json.Unmarshal(outBuf.Bytes(), &got))
+	want := map[string]any{
+		"data": map[string]any{
+			"comment":      `end */ here " quote`,
+			"config.ini":   "user=admin\nport=5432\n",
+			"keystore.bin": "<binary 5 bytes>",
+			"password":     "hunter2",
+		},
+		"kind": "Secret",
+	}
+	testutil.Equal(t, want, got)
+}
+
+type writerFunc func(b []byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
@@ -0,0 +1,118 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// CanIPrinter is used in "kubectl auth can-i" output, which is "yes" or "no",
+// optionally followed by a reason, e.g "no - no RBAC policy matched"
+type CanIPrinter struct {
+	Theme *config.Theme
+}
+
+// ensures it implements the interface
+var _ Printer = &CanIPrinter{}
+
+// Print implements [Printer.Print]
+func (p *CanIPrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+		answer, rest, _ := strings.Cut(line, " ")
+		switch answer {
+		case "yes":
+			fmt.Fprint(w, p.Theme.Auth.Yes.Render(answer))
+		case "no":
+			fmt.Fprint(w, p.Theme.Auth.No.Render(answer))
+		default:
+			fmt.Fprintln(w, p.Theme.Auth.Fallback.Render(line))
+			continue
+		}
+		if rest != "" {
+			fmt.Fprint(w, " ", rest)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print auth can-i output.", "error", err)
+	}
+}
+
+// newCanIListPrinter returns a printer for the "kubectl auth can-i --list" table,
+// where the verbs are colored by how risky they are:
+//
+//	Resources                                       Non-Resource URLs   Resource Names   Verbs
+//	*.*                                             []                  []               [*]
+//	pods                                            []                  []               [get list watch]
+func newCanIListPrinter(theme *config.Theme) *TablePrinter {
+	tablePrinter := NewTablePrinter(true, theme, func(columnIndex int, column string) string {
+		if columnIndex != 3 {
+			return column
+		}
+		return renderBracketList(column, func(verb string) string {
+			return authVerbColor(verb, theme).Render(verb)
+		})
+	})
+	tablePrinter.OnlyFirstLineHeader = true
+	return tablePrinter
+}
+
+// authVerbColor returns the color of an RBAC verb, by how much access it gives.
+func authVerbColor(verb string, theme *config.Theme) color.Color {
+	switch verb {
+	case "*", "delete", "deletecollection", "escalate", "bind", "impersonate":
+		return theme.Auth.VerbDanger
+	case "create", "update", "patch", "approve", "sign":
+		return theme.Auth.VerbWrite
+	case "get", "list", "watch":
+		return theme.Auth.VerbRead
+	default:
+		return color.Color{}
+	}
+}
+
+// newWhoAmIPrinter returns a printer for the "kubectl auth whoami" table,
+// where the attributes are colored as keys and the values as data:
+//
+//	ATTRIBUTE   VALUE
+//	Username    kubernetes-admin
+//	Groups      [kubeadm:cluster-admins system:authenticated]
+func newWhoAmIPrinter(theme *config.Theme) *TablePrinter {
+	return NewTablePrinter(true, theme, func(columnIndex int, column string) string {
+		if columnIndex == 0 {
+			return ColorDataKey(0, 2, theme.Data.Key).Render(column)
+		}
+		return renderBracketList(column, func(value string) string {
+			return ColorDataValue(value, theme).Render(value)
+		})
+	})
+}
+
+// renderBracketList renders each item of a list like "[get list watch]",
+// or the whole string if it isn't a list.
+func renderBracketList(s string, render func(item string) string) string {
+	inner, ok := strings.CutPrefix(s, "[")
+	if ok {
+		inner, ok = strings.CutSuffix(inner, "]")
+	}
+	if !ok {
+		return render(s)
+	}
+	if inner == "" {
+		return s
+	}
+	items := strings.Split(inner, " ")
+	for i, item := range items {
+		items[i] = render(item)
+	}
+	return "[" + strings.Join(items, " ") + "]"
+}
@@ -0,0 +1,41 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config/testconfig"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestCanIPrinter_fail(t *testing.T) {
+	var logBuf bytes.Buffer
+	testutil.SetTestLogger(t, &logBuf)
+
+	var outBuf bytes.Buffer
+	printer := CanIPrinter{Theme: testconfig.NullTheme}
+	printer.Print(testutil.DummyReader{ReadFunc: func(b []byte) (int, error) { return 0, errors.New("test") }}, &outBuf)
+
+	testutil.Equal(t, "", outBuf.String(), "output")
+	testutil.Equal(t, "level=ERROR msg=\"Failed to print auth can-i output.\" error=test\n", logBuf.String(), "logs")
+}
+
+func TestRenderBracketList(t *testing.T) {
+	wrap := func(s string) string { return "<" + s + ">" }
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"[get list watch]", "[<get> <list> <watch>]"},
+		{"[*]", "[<*>]"},
+		{"[]", "[]"},
+		{"kubernetes-admin", "<kubernetes-admin>"},
+		{"[unterminated", "<[unterminated>"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			testutil.Equal(t, tc.want, renderBracketList(tc.input, wrap))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+	"github.com/kubecolor/kubecolor/internal/stringutil"
+)
+
+// kubeconfigRedactKeys are the keys that are always masked in "kubectl config view",
+// in case it's used with "--raw".
+var kubeconfigRedactKeys = []string{
+	"client-key-data",
+	"token",
+	"password",
+}
+
+// newKubeconfigRedactor returns a [Redactor] for "kubectl config view",
+// which masks the credentials even when redaction isn't enabled.
+func newKubeconfigRedactor(r *Redactor, theme *config.Theme) *Redactor {
+	if r == nil {
+		return &Redactor{Theme: theme, Keys: kubeconfigRedactKeys}
+	}
+	return &Redactor{Theme: r.Theme, Keys: append(append([]string(nil), r.Keys...), kubeconfigRedactKeys...)}
+}
+
+// newContextsPrinter returns a printer for the "kubectl config get-contexts" table,
+// where the current context is highlighted, and the names of protected contexts,
+// e.g "prod-*", are colored with [config.ThemeConfig.Protected]:
+//
+//	CURRENT   NAME        CLUSTER     AUTHINFO    NAMESPACE
+//	*         kind-kind   kind-kind   kind-kind
+//	          prod-eu     prod-eu     prod-admin  default
+func newContextsPrinter(withHeader bool, theme *config.Theme, protected []string) *TablePrinter {
+	tablePrinter := NewTablePrinter(withHeader, theme, func(columnIndex int, column string) string {
+		switch {
+		case columnIndex == 0 && column == "*":
+			return theme.Config.Current.Render(column)
+		case columnIndex == 1 && stringutil.MatchesAnyGlob(column, protected):
+			return theme.Config.Protected.Render(column)
+		}
+		return column
+	})
+	tablePrinter.RowRules = []config.RowRule{
+		{Column: "CURRENT", Value: `\*`, Style: theme.Config.CurrentRow},
+	}
+	return tablePrinter
+}
+
+// CurrentContextPrinter is used in "kubectl config current-context" output,
+// which is only the name of the context.
+type CurrentContextPrinter struct {
+	Theme *config.Theme
+	// ProtectedContexts are glob patterns of context names to highlight, e.g "prod-*"
+	ProtectedContexts []string
+}
+
+// ensures it implements the interface
+var _ Printer = &CurrentContextPrinter{}
+
+// Print implements [Printer.Print]
+func (p *CurrentContextPrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+		fmt.Fprintln(w, p.contextColor(line).Render(line))
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print current context output.", "error", err)
+	}
+}
+
+func (p *CurrentContextPrinter) contextColor(name string) color.Color {
+	if stringutil.MatchesAnyGlob(name, p.ProtectedContexts) {
+		return p.Theme.Config.Protected
+	}
+	return p.Theme.Config.Context
+}
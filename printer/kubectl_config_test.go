@@ -0,0 +1,66 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestCurrentContextPrinter_protected(t *testing.T) {
+	theme := &config.Theme{
+		Config: config.ThemeConfig{
+			Context:   color.MustParse("green"),
+			Protected: color.MustParse("red"),
+		},
+	}
+	printer := CurrentContextPrinter{Theme: theme, ProtectedContexts: []string{"prod-*"}}
+
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader("prod-eu\n"), &outBuf)
+	testutil.Equal(t, "\x1b[31mprod-eu\x1b[0m\n", outBuf.String())
+
+	outBuf.Reset()
+	printer.Print(strings.NewReader("kind-kind\n"), &outBuf)
+	testutil.Equal(t, "\x1b[32mkind-kind\x1b[0m\n", outBuf.String())
+}
+
+func TestContextsPrinter_protected(t *testing.T) {
+	theme := &config.Theme{
+		Config: config.ThemeConfig{
+			Current:    color.MustParse("green"),
+			CurrentRow: color.MustParse("bold"),
+			Protected:  color.MustParse("red"),
+		},
+	}
+	printer := newContextsPrinter(true, theme, []string{"prod-*"})
+
+	input := testutil.NewHereDoc(`
+		CURRENT   NAME        CLUSTER     NAMESPACE
+		*         kind-kind   kind-kind
+		          prod-eu     prod-eu     *
+	`)
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	want := testutil.NewHereDoc(`
+		CURRENT   NAME        CLUSTER     NAMESPACE
+		\x1b[1m\x1b[32m*\x1b[0m\x1b[1m         kind-kind   kind-kind\x1b[0m
+		          \x1b[31mprod-eu\x1b[0m     prod-eu     *
+	`)
+	testutil.Equal(t, strings.ReplaceAll(want, `\x1b`, "\x1b"), outBuf.String())
+}
+
+func TestNewKubeconfigRedactor(t *testing.T) {
+	r := newKubeconfigRedactor(nil, &config.Theme{})
+	testutil.Equal(t, true, r.MatchKey("client-key-data"))
+	testutil.Equal(t, true, r.MatchKey("token"))
+	testutil.Equal(t, false, r.MatchKey("server"))
+
+	r = newKubeconfigRedactor(&Redactor{Keys: []string{"*secret*"}}, &config.Theme{})
+	testutil.Equal(t, true, r.MatchKey("my-secret"))
+	testutil.Equal(t, true, r.MatchKey("client-key-data"))
+}
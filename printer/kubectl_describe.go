@@ -6,8 +6,11 @@ import (
 	"io"
 	"log/slog"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
 	"github.com/kubecolor/kubecolor/internal/bytesutil"
 	"github.com/kubecolor/kubecolor/scanner/describe"
 )
@@ -15,8 +18,15 @@ import (
 // DescribePrinter is used on "kubectl describe" output
 type DescribePrinter struct {
 	TablePrinter *TablePrinter
+	Redactor     *Redactor
+	// Allocation holds the thresholds for the allocated resources in "kubectl describe node".
+	Allocation config.Allocation
 
 	tableBytes *bytes.Buffer
+	// tableSection is the top-level key of the current table, e.g "Conditions"
+	tableSection string
+	// conditionType is the type of the current row in the conditions table, e.g "Ready"
+	conditionType string
 }
 
 // ensures it implements the interface
@@ -38,12 +48,15 @@ func (p *DescribePrinter) Print(r io.Reader, w io.Writer) {
 		} else if bytesutil.CountColumns(line.Value, " \t") >= 3 { // when there are multiple columns, treat is as table format
 			if p.tableBytes == nil {
 				p.tableBytes = &bytes.Buffer{}
+				p.tableSection = ""
+				if path := scanner.Path(); len(path) > 0 {
+					p.tableSection = path[0].Segment
+				}
 			}
 			fmt.Fprintln(p.tableBytes, line.String())
 			continue
 		} else if p.tableBytes != nil {
-			p.TablePrinter.Print(p.tableBytes, w)
-			p.tableBytes = nil
+			p.printTable(w)
 		}
 
 		fmt.Fprintf(w, "%s", line.Indent)
@@ -59,8 +72,15 @@ func (p *DescribePrinter) Print(r io.Reader, w io.Writer) {
 		fmt.Fprintf(w, "%s", line.Spacing)
 		if len(line.Value) > 0 {
 			val := string(line.Value)
-			if k, v, ok := strings.Cut(val, ": "); ok { // split annotation and env from
-				fmt.Fprint(w, k, ": ", p.colorize(scanner.Path(), v))
+			if p.Redactor.MatchKey(strings.TrimSuffix(string(line.Key), ":")) && isRedactable(strings.TrimSpace(val)) {
+				// e.g environment variables like "DB_PASSWORD:  hunter2"
+				fmt.Fprint(w, p.Redactor.Render())
+			} else if k, v, ok := strings.Cut(val, ": "); ok { // split annotation and env from
+				if p.Redactor.MatchKey(k) && isRedactable(strings.TrimSpace(v)) {
+					fmt.Fprint(w, k, ": ", p.Redactor.Render())
+				} else {
+					fmt.Fprint(w, k, ": ", p.colorize(scanner.Path(), v))
+				}
 			} else if k, v, ok := strings.Cut(val, "="); ok { // split label
 				fmt.Fprint(w, k, "=", p.colorize(scanner.Path(), v))
 			} else {
@@ -74,9 +94,44 @@ func (p *DescribePrinter) Print(r io.Reader, w io.Writer) {
 	}
 
 	if p.tableBytes != nil {
+		p.printTable(w)
+	}
+}
+
+// printTable prints the buffered table, with extra colors for the tables
+// in some sections, such as the allocated resources in "kubectl describe node".
+func (p *DescribePrinter) printTable(w io.Writer) {
+	switch p.tableSection {
+	case "Conditions":
+		p.sectionTablePrinter(p.colorizeConditionCell).Print(p.tableBytes, w)
+	case "Allocated resources", "Non-terminated Pods":
+		p.sectionTablePrinter(p.colorizeAllocationCell).Print(p.tableBytes, w)
+	default:
 		p.TablePrinter.Print(p.tableBytes, w)
-		p.tableBytes = nil
 	}
+	p.tableBytes = nil
+}
+
+// sectionTablePrinter returns a copy of the [DescribePrinter.TablePrinter],
+// where cells are first colored by the given function, which gets the column
+// name of the cell from the table header, e.g "Status".
+func (p *DescribePrinter) sectionTablePrinter(colorize func(columnIndex int, columnName, cell string) (string, bool)) *TablePrinter {
+	tablePrinter := *p.TablePrinter
+	tablePrinter.columns = nil
+	tablePrinter.ColumnFilter = func(columnIndex int, column string) string {
+		var columnName string
+		if columnIndex < len(tablePrinter.columns) {
+			columnName = tablePrinter.columns[columnIndex]
+		}
+		if colored, ok := colorize(columnIndex, columnName, column); ok {
+			return colored
+		}
+		if p.TablePrinter.ColumnFilter != nil {
+			return p.TablePrinter.ColumnFilter(columnIndex, column)
+		}
+		return column
+	}
+	return &tablePrinter
 }
 
 func (p *DescribePrinter) colorize(path describe.Path, value string) string {
@@ -85,6 +140,9 @@ func (p *DescribePrinter) colorize(path describe.Path, value string) string {
 	if col, ok := p.colorizeStatus(value, pathStr); ok {
 		return col
 	}
+	if col, ok := p.colorizeCondition(value, path); ok {
+		return col
+	}
 	if col, ok := p.colorizeArgs(value, pathStr); ok {
 		return col
 	}
@@ -125,6 +183,103 @@ func (p *DescribePrinter) colorizeArgs(value, pathStr string) (string, bool) {
 	return value, true
 }
 
+// colorizeCondition colors the conditions that aren't printed as a table,
+// e.g "Ready  True" in "kubectl describe pod".
+func (p *DescribePrinter) colorizeCondition(value string, path describe.Path) (string, bool) {
+	if len(path) != 2 || !path.HasPrefix("Conditions") {
+		return value, false
+	}
+	c, ok := conditionColor(path[1].Segment, value, p.TablePrinter.Theme)
+	if !ok {
+		return value, false
+	}
+	return c.Render(value), true
+}
+
+// colorizeConditionCell colors the "Status" cells in the conditions table, e.g in "kubectl describe node":
+//
+//	Type             Status  Reason
+//	MemoryPressure   False   KubeletHasSufficientMemory
+//	Ready            True    KubeletReady
+func (p *DescribePrinter) colorizeConditionCell(_ int, columnName, cell string) (string, bool) {
+	if columnName == "Type" {
+		// the type comes before the status, so remember it for the status cell
+		p.conditionType = cell
+		return cell, false
+	}
+	if columnName != "Status" {
+		return cell, false
+	}
+	c, ok := conditionColor(p.conditionType, cell, p.TablePrinter.Theme)
+	if !ok {
+		return cell, false
+	}
+	return c.Render(cell), true
+}
+
+// problemConditionSuffixes are the suffixes of condition types where "True"
+// means there's a problem, e.g "MemoryPressure" or "NetworkUnavailable".
+var problemConditionSuffixes = []string{"Pressure", "Unavailable", "Failure", "Failed"}
+
+// conditionColor returns the color of a condition status, depending on
+// whether "True" is good or bad for the condition type.
+func conditionColor(conditionType, status string, theme *config.Theme) (color.Color, bool) {
+	good := "True"
+	for _, suffix := range problemConditionSuffixes {
+		if strings.HasSuffix(conditionType, suffix) {
+			good = "False"
+			break
+		}
+	}
+	switch status {
+	case "True", "False":
+		if status == good {
+			return theme.Describe.ConditionGood, true
+		}
+		return theme.Describe.ConditionBad, true
+	case "Unknown":
+		return theme.Describe.ConditionUnknown, true
+	}
+	return color.Color{}, false
+}
+
+// allocationRegex matches the allocated resources, e.g "3800m (95%)"
+var allocationRegex = regexp.MustCompile(`^\S+ \((\d+)%\)$`)
+
+// colorizeAllocationCell colors the requests and limits cells by their percentage
+// of the node allocatable resources, e.g in "kubectl describe node":
+//
+//	Resource           Requests     Limits
+//	cpu                3800m (95%)  8 (200%)
+func (p *DescribePrinter) colorizeAllocationCell(_ int, columnName, cell string) (string, bool) {
+	var threshold int
+	switch {
+	case strings.HasSuffix(columnName, "Requests"):
+		threshold = p.Allocation.Requests
+	case strings.HasSuffix(columnName, "Limits"):
+		threshold = p.Allocation.Limits
+	default:
+		return cell, false
+	}
+	m := allocationRegex.FindStringSubmatch(cell)
+	if m == nil {
+		return cell, false
+	}
+	percent, err := strconv.Atoi(m[1])
+	if err != nil {
+		return cell, false
+	}
+	theme := p.TablePrinter.Theme
+	switch {
+	case percent > 100:
+		return theme.Describe.AllocationOver.Render(cell), true
+	case percent >= threshold:
+		return theme.Describe.AllocationHigh.Render(cell), true
+	default:
+		return theme.Describe.Allocation.Render(cell), true
+	}
+}
+
 func matchesAnyRegex(s string, regexes []*regexp.Regexp) bool {
 	for _, r := range regexes {
 		if r.MatchString(s) {
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
 	"github.com/kubecolor/kubecolor/testutil"
 )
 
@@ -19,3 +21,66 @@ func TestDescribePrinter_fail(t *testing.T) {
 	testutil.Equal(t, "", outBuf.String(), "output")
 	testutil.Equal(t, "level=ERROR msg=\"Failed to print describe output.\" error=test\n", logBuf.String(), "logs")
 }
+
+func TestDescribePrinter_colorizeAllocationCell(t *testing.T) {
+	theme := &config.Theme{
+		Describe: config.ThemeDescribe{
+			Allocation:     color.MustParse("green"),
+			AllocationHigh: color.MustParse("yellow"),
+			AllocationOver: color.MustParse("red"),
+		},
+	}
+	printer := DescribePrinter{
+		TablePrinter: &TablePrinter{Theme: theme},
+		Allocation:   config.Allocation{Requests: 80, Limits: 90},
+	}
+	tests := []struct {
+		column string
+		cell   string
+		want   string
+	}{
+		{column: "Requests", cell: "650m (10%)", want: "\x1b[32m650m (10%)\x1b[0m"},
+		{column: "Requests", cell: "3200m (80%)", want: "\x1b[33m3200m (80%)\x1b[0m"},
+		{column: "Limits", cell: "3200m (80%)", want: "\x1b[32m3200m (80%)\x1b[0m"},
+		{column: "CPU Limits", cell: "8 (200%)", want: "\x1b[31m8 (200%)\x1b[0m"},
+		{column: "Age", cell: "7d21h", want: "7d21h"},
+		{column: "Requests", cell: "0", want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column+" "+tt.cell, func(t *testing.T) {
+			got, _ := printer.colorizeAllocationCell(0, tt.column, tt.cell)
+			testutil.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConditionColor(t *testing.T) {
+	theme := &config.Theme{
+		Describe: config.ThemeDescribe{
+			ConditionGood:    color.MustParse("green"),
+			ConditionBad:     color.MustParse("red"),
+			ConditionUnknown: color.MustParse("yellow"),
+		},
+	}
+	tests := []struct {
+		conditionType string
+		status        string
+		want          color.Color
+		wantOK        bool
+	}{
+		{conditionType: "Ready", status: "True", want: theme.Describe.ConditionGood, wantOK: true},
+		{conditionType: "Ready", status: "False", want: theme.Describe.ConditionBad, wantOK: true},
+		{conditionType: "Ready", status: "Unknown", want: theme.Describe.ConditionUnknown, wantOK: true},
+		{conditionType: "MemoryPressure", status: "False", want: theme.Describe.ConditionGood, wantOK: true},
+		{conditionType: "DiskPressure", status: "True", want: theme.Describe.ConditionBad, wantOK: true},
+		{conditionType: "NetworkUnavailable", status: "True", want: theme.Describe.ConditionBad, wantOK: true},
+		{conditionType: "Ready", status: "KubeletReady", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.conditionType+" "+tt.status, func(t *testing.T) {
+			got, ok := conditionColor(tt.conditionType, tt.status, theme)
+			testutil.Equal(t, tt.wantOK, ok)
+			testutil.Equal(t, tt.want.String(), got.String())
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/stringutil"
+	"github.com/kubecolor/kubecolor/scanner/tablescan"
+)
+
+// EventsPrinter is used on "kubectl events" and "kubectl get events" table output.
+//
+// Tables that aren't events, e.g the pods in "kubectl get pods,events",
+// are printed by the TablePrinter instead.
+type EventsPrinter struct {
+	TablePrinter      *TablePrinter
+	Theme             *config.Theme
+	ObjFreshThreshold config.DurationSlice
+
+	// columns holds the header names of the current table,
+	// or nil if the current table is not an events table.
+	columns []string
+}
+
+// ensures it implements the interface
+var _ Printer = &EventsPrinter{}
+
+// Print implements [Printer.Print]
+func (p *EventsPrinter) Print(r io.Reader, w io.Writer) {
+	isFirstLine := true
+	scanner := tablescan.NewScanner(r)
+	for scanner.Scan() {
+		cells := scanner.Cells()
+		if len(cells) == 0 {
+			fmt.Fprint(w, "\n")
+			continue
+		}
+		if p.TablePrinter.isHeader(scanner, isFirstLine) {
+			isFirstLine = false
+			p.TablePrinter.printHeader(w, scanner)
+			p.columns = eventsColumns(cells)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s", scanner.LeadingSpaces())
+		if p.columns == nil {
+			p.TablePrinter.printLineAsTableFormat(w, cells, p.Theme.Table.Columns)
+			continue
+		}
+		p.printEventLine(w, cells)
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print events output.", "error", err)
+	}
+}
+
+// eventsColumns returns the column names of the header,
+// or nil if the header doesn't look like an events table.
+//
+//	LAST SEEN   TYPE      REASON    OBJECT      MESSAGE
+func eventsColumns(cells []tablescan.Cell) []string {
+	columns := make([]string, len(cells))
+	var hasType, hasReason bool
+	for i, cell := range cells {
+		columns[i] = strings.ToUpper(cell.Trimmed)
+		switch columns[i] {
+		case "TYPE":
+			hasType = true
+		case "REASON":
+			hasReason = true
+		}
+	}
+	if !hasType || !hasReason {
+		return nil
+	}
+	return columns
+}
+
+// printEventLine prints a row of an events table, such as:
+//
+//	43s (x150 over 21h)   Warning   BackOff   Pod/nginx-7b9f   Back-off restarting failed container
+func (p *EventsPrinter) printEventLine(w io.Writer, cells []tablescan.Cell) {
+	var line strings.Builder
+	isWarning := false
+	for i, cell := range cells {
+		if i < len(p.columns) && p.columns[i] == "TYPE" && cell.Trimmed == "Warning" {
+			isWarning = true
+		}
+	}
+
+	for i, cell := range cells {
+		if cell.Trimmed != "" {
+			rowColor := p.TablePrinter.getColumnBaseColor(i, p.Theme.Table.Columns)
+			if isWarning {
+				rowColor = p.Theme.Events.WarningRow
+			}
+			var column string
+			if i < len(p.columns) {
+				column = p.columns[i]
+			}
+			line.WriteString(p.colorCell(column, cell.Trimmed, rowColor))
+		}
+		line.WriteString(cell.TrailingSpaces)
+	}
+	fmt.Fprintf(w, "%s\n", p.TablePrinter.renderRow(cells, line.String()))
+}
+
+// colorCell colors a cell based on its column name, and falls back
+// to the rowColor for columns without any specific coloring.
+func (p *EventsPrinter) colorCell(column, text string, rowColor color.Color) string {
+	if column == "NAMESPACE" {
+		if c, ok := p.TablePrinter.Identity.Color(text); ok {
+			return c.Render(text)
+		}
+	}
+	switch column {
+	case "LAST SEEN", "FIRST SEEN", "AGE":
+		return p.colorAge(text, rowColor)
+	case "TYPE":
+		switch text {
+		case "Normal":
+			return p.Theme.Events.Normal.Render(text)
+		case "Warning":
+			return p.Theme.Events.Warning.Render(text)
+		}
+	case "REASON":
+		if colored, ok := ColorStatus(text, p.Theme); ok {
+			return colored
+		}
+	case "OBJECT":
+		// Pod/nginx-7b9f
+		if kind, name, ok := strings.Cut(text, "/"); ok {
+			if colored, ok := p.TablePrinter.Identity.RenderKindPrefix(kind + "/"); ok {
+				return colored + rowColor.Render(name)
+			}
+			return p.Theme.Events.ObjectKind.Render(kind) + rowColor.Render("/"+name)
+		}
+	}
+	return rowColor.Render(text)
+}
+
+// colorAge colors the age of an event by how fresh it is,
+// with any repetition count in a more muted color, e.g "43s (x150 over 21h)".
+func (p *EventsPrinter) colorAge(text string, rowColor color.Color) string {
+	ageText, repeated, hasRepeated := strings.Cut(text, " (")
+	ageColor := rowColor
+	if ageText == "<unknown>" || ageText == "<invalid>" {
+		ageColor = p.Theme.Data.Null
+	} else if age, ok := stringutil.ParseHumanDuration(ageText); ok {
+		if c := ColorDuration(age, p.ObjFreshThreshold, p.Theme); !c.IsNoop() {
+			ageColor = c
+		}
+	}
+	if !hasRepeated {
+		return ageColor.Render(ageText)
+	}
+	return ageColor.Render(ageText) + " " + p.Theme.Events.Repeated.Render("("+repeated)
+}
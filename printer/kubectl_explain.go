@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"regexp"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
@@ -16,53 +17,243 @@ import (
 type ExplainPrinter struct {
 	Theme     *config.Theme
 	Recursive bool
+	// Tree draws guide lines for the nesting of fields, e.g "├─ apiVersion",
+	// when used together with Recursive.
+	Tree bool
 }
 
 // ensures it implements the interface
 var _ Printer = &ExplainPrinter{}
 
+// explainTypeRegex matches the type annotation of a field, e.g "<[]string>" in "<[]string> -required-"
+var explainTypeRegex = regexp.MustCompile(`<[^<>\s]+>`)
+
+// explainLine is a line of "kubectl explain" output,
+// together with the information needed from the lines around it.
+type explainLine struct {
+	describe.Line
+	// Section is the top-level heading the line is in, e.g "FIELDS" or "DESCRIPTION"
+	Section string
+	// Depth is the nesting of the field, starting at 0 for the fields directly under "FIELDS",
+	// or -1 if the line is not a field.
+	Depth      int
+	Deprecated bool
+}
+
+func newExplainLine(line describe.Line, path describe.Path) explainLine {
+	explain := explainLine{
+		// Clone it, as the line is reused by the next [describe.Scanner.Scan]
+		Line: describe.Line{
+			Indent:   bytes.Clone(line.Indent),
+			Key:      bytes.Clone(line.Key),
+			Spacing:  bytes.Clone(line.Spacing),
+			Value:    bytes.Clone(line.Value),
+			Trailing: bytes.Clone(line.Trailing),
+		},
+		Depth: -1,
+	}
+	if len(path) > 0 {
+		explain.Section = path[0].Segment
+	}
+	if explain.Section == "FIELDS" && len(line.Key) > 0 && bytes.HasPrefix(line.Value, []byte("<")) {
+		explain.Depth = len(path) - 2
+	}
+	return explain
+}
+
+func (line explainLine) isField() bool {
+	return line.Depth >= 0
+}
+
 // Print implements [Printer.Print]
 func (p *ExplainPrinter) Print(r io.Reader, w io.Writer) {
+	// Buffer all lines, as the deprecation of a field is only known from
+	// the description below it, and the tree guides depends on the fields below.
+	var lines []explainLine
 	scanner := describe.NewScanner(r)
 	for scanner.Scan() {
-		line := scanner.Line()
-		isFields := scanner.Path().HasPrefix("FIELDS")
-
-		fmt.Fprintf(w, "%s", line.Indent)
-		if bytes.ContainsAny(line.Key, " \t-.") {
-			fmt.Fprintf(w, "%s", line.Key)
-		} else if len(line.Key) > 0 {
-			keyColor := p.keyColor(line, isFields)
-			key := string(line.Key)
-			if withoutColon, ok := strings.CutSuffix(key, ":"); ok {
-				fmt.Fprint(w, keyColor.Render(withoutColon), ":")
-			} else {
-				fmt.Fprint(w, keyColor.Render(key))
-			}
-		}
-		fmt.Fprintf(w, "%s", line.Spacing)
-		p.printVal(w, string(line.Value))
-		fmt.Fprintf(w, "%s\n", line.Trailing)
+		lines = append(lines, newExplainLine(scanner.Line(), scanner.Path()))
 	}
 	if err := scanner.Err(); err != nil {
 		slog.Error("Failed to print explain output.", "error", err)
 	}
+
+	markDeprecatedExplainFields(lines)
+	var guides []string
+	if p.Tree && p.Recursive {
+		guides = explainTreeGuides(lines)
+	}
+
+	for i, line := range lines {
+		if guides != nil && guides[i] != "" {
+			fmt.Fprint(w, p.Theme.Explain.Tree.Render(guides[i]))
+		} else {
+			fmt.Fprintf(w, "%s", line.Indent)
+		}
+		p.printLine(w, line)
+		fmt.Fprintf(w, "%s\n", line.Trailing)
+	}
+}
+
+func (p *ExplainPrinter) printLine(w io.Writer, line explainLine) {
+	key := string(line.Key)
+	switch {
+	case line.isField():
+		keyColor := p.keyColor(line)
+		if line.Deprecated {
+			keyColor = p.Theme.Explain.Deprecated
+		}
+		fmt.Fprint(w, keyColor.Render(key))
+		fmt.Fprintf(w, "%s", line.Spacing)
+		p.printVal(w, string(line.Value), line.Deprecated)
+		return
+
+	case len(line.Indent) == 0 && isExplainHeading(key):
+		// e.g "KIND:       Pod" or "FIELD: imagePullPolicy <string>"
+		heading, rest, _ := strings.Cut(key, ":")
+		fmt.Fprint(w, p.Theme.Explain.Header.Render(heading+":"))
+		if rest != "" {
+			// e.g " imagePullPolicy <string>"
+			name, typ, _ := strings.Cut(strings.TrimPrefix(rest, " "), " ")
+			fmt.Fprint(w, " ", p.Theme.Explain.Resource.Render(name))
+			if typ != "" {
+				fmt.Fprint(w, " ")
+				p.printVal(w, typ, false)
+			}
+		}
+		fmt.Fprintf(w, "%s", line.Spacing)
+		switch heading {
+		case "GROUP", "KIND", "VERSION":
+			fmt.Fprint(w, p.Theme.Explain.Resource.Render(string(line.Value)))
+		default:
+			p.printVal(w, string(line.Value), false)
+		}
+		return
+
+	case line.Section == "ENUM" && len(line.Key) == 0:
+		// ENUM:
+		//     Always
+		fmt.Fprintf(w, "%s", line.Spacing)
+		fmt.Fprint(w, p.Theme.Explain.Enum.Render(string(line.Value)))
+		return
+
+	case strings.HasPrefix(key, "enum: "):
+		// enum: Always, IfNotPresent, Never
+		fmt.Fprint(w, p.keyColor(line).Render("enum"), ": ")
+		for i, value := range strings.Split(strings.TrimPrefix(key, "enum: "), ", ") {
+			if i > 0 {
+				fmt.Fprint(w, ", ")
+			}
+			fmt.Fprint(w, p.Theme.Explain.Enum.Render(value))
+		}
+		fmt.Fprintf(w, "%s", line.Spacing)
+		p.printVal(w, string(line.Value), false)
+		return
+
+	case bytes.ContainsAny(line.Key, " \t-."):
+		fmt.Fprintf(w, "%s", line.Key)
+
+	case len(line.Key) > 0:
+		keyColor := p.keyColor(line)
+		if withoutColon, ok := strings.CutSuffix(key, ":"); ok {
+			fmt.Fprint(w, keyColor.Render(withoutColon), ":")
+		} else {
+			fmt.Fprint(w, keyColor.Render(key))
+		}
+	}
+	fmt.Fprintf(w, "%s", line.Spacing)
+	p.printVal(w, string(line.Value), false)
 }
 
-func (p *ExplainPrinter) keyColor(line describe.Line, isFields bool) color.Color {
-	if p.Recursive && isFields {
+func (p *ExplainPrinter) keyColor(line explainLine) color.Color {
+	if p.Recursive && line.Section == "FIELDS" {
 		return ColorDataKey(line.KeyIndent(), 2, p.Theme.Explain.Key)
 	}
 
 	return ColorDataKey(0, 2, p.Theme.Explain.Key)
 }
 
-func (p *ExplainPrinter) printVal(w io.Writer, val string) {
+func (p *ExplainPrinter) printVal(w io.Writer, val string, deprecated bool) {
 	const suffix = "-required-"
-	if withoutSuffix, ok := strings.CutSuffix(val, suffix); ok {
-		fmt.Fprint(w, withoutSuffix)
+	withoutSuffix, isRequired := strings.CutSuffix(val, suffix)
+
+	if strings.HasPrefix(withoutSuffix, "<") {
+		// e.g "<[]string>"
+		withoutSuffix = explainTypeRegex.ReplaceAllStringFunc(withoutSuffix, func(typ string) string {
+			if deprecated {
+				return p.Theme.Explain.Deprecated.Render(typ)
+			}
+			return p.explainTypeColor(typ).Render(typ)
+		})
+	}
+	fmt.Fprint(w, withoutSuffix)
+	if isRequired {
 		fmt.Fprint(w, p.Theme.Explain.Required.Render(suffix))
-		return
 	}
-	fmt.Fprint(w, val)
+}
+
+// explainTypeColor returns the color of the type family, e.g "<[]string>" is a collection.
+func (p *ExplainPrinter) explainTypeColor(typ string) color.Color {
+	name := strings.TrimSuffix(strings.TrimPrefix(typ, "<"), ">")
+	switch {
+	case strings.HasPrefix(name, "[]"), strings.HasPrefix(name, "map["):
+		return p.Theme.Explain.Type.Collection
+	}
+	switch name {
+	case "string", "byte":
+		return p.Theme.Explain.Type.String
+	case "integer", "number", "int32", "int64":
+		return p.Theme.Explain.Type.Number
+	case "boolean":
+		return p.Theme.Explain.Type.Boolean
+	default:
+		// e.g "<Object>", "<ObjectMeta>", "<Quantity>"
+		return p.Theme.Explain.Type.Object
+	}
+}
+
+// isExplainHeading returns true on the top-level headings, e.g "KIND:", "FIELDS:",
+// and "FIELD: imagePullPolicy <string>"
+func isExplainHeading(key string) bool {
+	heading, _, ok := strings.Cut(key, ":")
+	return ok && heading != "" && isAllUpper(heading) && !strings.ContainsAny(heading, " \t")
+}
+
+// markDeprecatedExplainFields marks fields whose name starts with "deprecated",
+// e.g "deprecatedTopology", or whose description starts with "Deprecated",
+// e.g "Deprecated: Use serviceAccountName instead."
+func markDeprecatedExplainFields(lines []explainLine) {
+	field := -1
+	for i, line := range lines {
+		if line.isField() {
+			field = i
+			name := strings.ToLower(string(line.Key))
+			lines[i].Deprecated = strings.HasPrefix(name, "deprecated")
+			continue
+		}
+		if field == -1 || len(line.Key) > 0 {
+			continue
+		}
+		if description := string(line.Value); strings.HasPrefix(description, "Deprecated") ||
+			strings.HasPrefix(description, "DEPRECATED") ||
+			strings.Contains(description, "is a deprecated alias") {
+			lines[field].Deprecated = true
+		}
+	}
+}
+
+// explainTreeGuides returns the tree guides to use instead of the indentation
+// of each field, or an empty string for lines that aren't fields, e.g:
+//
+//	FIELDS:
+//	├─ apiVersion	<string>
+//	├─ metadata	<ObjectMeta>
+//	│  └─ annotations	<map[string]string>
+//	└─ spec	<PodSpec>
+func explainTreeGuides(lines []explainLine) []string {
+	depths := make([]int, len(lines))
+	for i, line := range lines {
+		depths[i] = line.Depth
+	}
+	return treeGuides(depths)
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"log/slog"
+	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
 	"github.com/kubecolor/kubecolor/config/color"
@@ -12,7 +13,9 @@ import (
 
 // LogsPrinter is used in "kubectl logs" output:
 type LogsPrinter struct {
-	Theme *config.Theme
+	Theme    *config.Theme
+	Redactor *Redactor
+	Identity *IdentityColors
 }
 
 // ensures it implements the interface
@@ -25,9 +28,35 @@ func (p *LogsPrinter) Print(r io.Reader, w io.Writer) {
 	// Buffer the lines so we can write them to the io.Writer all at once
 	var lineBuffer bytes.Buffer
 	var keyIndex int
+	// lastKey is the key of the current key-value pair, e.g "password" in "password=hunter2"
+	var lastKey string
+	// afterBearer is true after a "Bearer" word, so the next word is a token
+	var afterBearer bool
+	// lineTokenIndex is the index of the token on the current line, used to
+	// find the "[pod/nginx/nginx]" prefix added by "kubectl logs --prefix"
+	var lineTokenIndex int
+	var lastToken logscan.Token
 
 	for scanner.Scan() {
 		token := scanner.Token()
+		isPrefix := lineTokenIndex == 1 && lastToken.Text == "["
+		lastToken = token
+		lineTokenIndex++
+
+		if p.Redactor != nil {
+			if p.redactToken(&lineBuffer, token, lastKey, afterBearer) {
+				afterBearer = false
+				continue
+			}
+			switch {
+			case token.Kind == logscan.KindKey:
+				lastKey = strings.Trim(token.Text, `"'`)
+			case token.Kind == logscan.KindUnknown && strings.EqualFold(token.Text, "bearer"):
+				afterBearer = true
+			case token.Kind != logscan.KindUnknown || strings.TrimSpace(token.Text) != "":
+				afterBearer = false
+			}
+		}
 
 		switch token.Kind {
 		case logscan.KindKey:
@@ -69,8 +98,16 @@ func (p *LogsPrinter) Print(r io.Reader, w io.Writer) {
 			lineBuffer.WriteTo(w)
 			lineBuffer.Reset()
 			keyIndex = 0
+			lastKey = ""
+			lineTokenIndex = 0
 
 		default:
+			if isPrefix {
+				if colored, ok := p.Identity.RenderKindPrefix(token.Text); ok {
+					lineBuffer.WriteString(colored)
+					continue
+				}
+			}
 			if c, ok := TryColorDataValue(token.Text, p.Theme); ok {
 				lineBuffer.WriteString(c.Render(token.Text))
 			} else {
@@ -82,3 +119,36 @@ func (p *LogsPrinter) Print(r io.Reader, w io.Writer) {
 		slog.Error("Failed to print log output.", "error", err)
 	}
 }
+
+// redactToken writes the token with any secrets masked by the redactor.
+// Returns false if the token doesn't contain any secrets.
+func (p *LogsPrinter) redactToken(buf *bytes.Buffer, token logscan.Token, lastKey string, afterBearer bool) bool {
+	switch token.Kind {
+	case logscan.KindValue:
+		quote, unquoted := cutLogQuotes(token.Text)
+		if p.Redactor.MatchKey(lastKey) && isRedactable(unquoted) {
+			buf.WriteString(quote + p.Redactor.Render() + quote)
+			return true
+		}
+	case logscan.KindUnknown:
+		if afterBearer && strings.TrimSpace(token.Text) != "" && isRedactable(token.Text) {
+			buf.WriteString(p.Redactor.Render())
+			return true
+		}
+	}
+	if token.Kind == logscan.KindValue || token.Kind == logscan.KindQuote {
+		// e.g "Authorization: Bearer eyJhbGciOi..." inside a quoted string
+		if s, ok := p.Redactor.RedactBearerTokens(token.Text, p.Theme.Data.String.Render); ok {
+			buf.WriteString(s)
+			return true
+		}
+	}
+	return false
+}
+
+func cutLogQuotes(s string) (quote, unquoted string) {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[:1], s[1 : len(s)-1]
+	}
+	return "", s
+}
@@ -0,0 +1,220 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// ocQuotedRegex matches the quoted values in "oc project" output, e.g
+// `project "foo"` and `server "https://api.example.com:6443"`
+var ocQuotedRegex = regexp.MustCompile(`(project|server) "([^"]*)"`)
+
+// ProjectPrinter is used in the OpenShift "oc project", "oc projects",
+// and "oc new-project" output:
+//
+//	You have access to the following projects and can switch between them with 'oc project <projectname>':
+//
+//	    default
+//	  * my-project
+//
+//	Using project "my-project" on server "https://api.example.com:6443".
+type ProjectPrinter struct {
+	Theme *config.Theme
+}
+
+// ensures it implements the interface
+var _ Printer = &ProjectPrinter{}
+
+// Print implements [Printer.Print]
+func (p *ProjectPrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		fmt.Fprintln(w, p.renderLine(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print project output.", "error", err)
+	}
+}
+
+func (p *ProjectPrinter) renderLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	switch {
+	case trimmed == "":
+		return line
+	case indent != "" && strings.HasPrefix(trimmed, "* ") && !strings.Contains(trimmed[2:], " "):
+		// the current project in the list, e.g "  * my-project"
+		return indent + p.Theme.OC.Current.Render(trimmed)
+	case indent != "" && !strings.Contains(trimmed, " "):
+		// the other projects in the list, e.g "    default"
+		return indent + p.Theme.OC.Project.Render(trimmed)
+	case ocQuotedRegex.MatchString(line):
+		return p.renderQuoted(line)
+	default:
+		return p.Theme.OC.Fallback.Render(line)
+	}
+}
+
+// renderQuoted colors the quoted project and server in e.g
+// `Now using project "foo" on server "https://api.example.com:6443".`
+func (p *ProjectPrinter) renderQuoted(line string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range ocQuotedRegex.FindAllStringSubmatchIndex(line, -1) {
+		sb.WriteString(p.Theme.OC.Fallback.Render(line[last:m[4]]))
+		value := line[m[4]:m[5]]
+		if line[m[2]:m[3]] == "project" {
+			sb.WriteString(p.Theme.OC.Project.Render(value))
+		} else {
+			sb.WriteString(p.Theme.OC.URL.Render(value))
+		}
+		last = m[5]
+	}
+	sb.WriteString(p.Theme.OC.Fallback.Render(line[last:]))
+	return sb.String()
+}
+
+var (
+	// ocStatusProjectRegex matches the first line of "oc status", e.g
+	// "In project my-project on server https://api.example.com:6443"
+	ocStatusProjectRegex = regexp.MustCompile(`^(In project )(\S+)(.* on server )(\S+)(.*)$`)
+	// ocResourceRegex matches resource references, e.g "svc/nginx" or "dc/frontend"
+	ocResourceRegex = regexp.MustCompile(`^[a-z][a-z0-9.-]*/[^\s/]+$`)
+	ocWordRegex     = regexp.MustCompile(`\S+`)
+)
+
+// ProjectStatusPrinter is used in the OpenShift "oc status" output, where
+// the nested lines of each app are drawn as a tree:
+//
+//	In project my-project on server https://api.example.com:6443
+//
+//	http://frontend-my-project.apps.example.com (svc/frontend)
+//	  dc/frontend deploys istag/frontend:latest <-
+//	    bc/frontend source builds https://github.com/sclorg/nodejs-ex.git on openshift/nodejs:16-ubi8
+//	    deployment #2 running for 5 minutes - 1 pod
+//
+// is printed as:
+//
+//	http://frontend-my-project.apps.example.com (svc/frontend)
+//	└─ dc/frontend deploys istag/frontend:latest <-
+//	   ├─ bc/frontend source builds https://github.com/sclorg/nodejs-ex.git on openshift/nodejs:16-ubi8
+//	   └─ deployment #2 running for 5 minutes - 1 pod
+type ProjectStatusPrinter struct {
+	Theme *config.Theme
+}
+
+// ensures it implements the interface
+var _ Printer = &ProjectStatusPrinter{}
+
+// Print implements [Printer.Print]
+func (p *ProjectStatusPrinter) Print(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	// block holds the nested lines below the last top-level line,
+	// as the tree guides depend on the lines that follows
+	var block []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if p.isNested(line) {
+			block = append(block, line)
+			continue
+		}
+		p.printBlock(w, block)
+		block = block[:0]
+		fmt.Fprintln(w, p.renderTopLevel(line))
+	}
+	p.printBlock(w, block)
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print status output.", "error", err)
+	}
+}
+
+// isNested returns true for the indented lines that are part of a tree,
+// but not the bullet points below e.g "Errors:"
+func (p *ProjectStatusPrinter) isNested(line string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return trimmed != "" && len(trimmed) < len(line) && !strings.HasPrefix(trimmed, "* ")
+}
+
+func (p *ProjectStatusPrinter) printBlock(w io.Writer, block []string) {
+	if len(block) == 0 {
+		return
+	}
+	depths := make([]int, len(block))
+	for i, line := range block {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		depths[i] = max(indent/2-1, 0)
+	}
+	for i, guide := range treeGuides(depths) {
+		fmt.Fprintf(w, "%s%s\n", p.Theme.OC.Tree.Render(guide), p.renderWords(strings.TrimLeft(block[i], " ")))
+	}
+}
+
+func (p *ProjectStatusPrinter) renderTopLevel(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return line
+	case trimmed == "Errors:" || trimmed == "Warnings:" || trimmed == "Info:":
+		return p.Theme.OC.Header.Render(line)
+	}
+	if m := ocStatusProjectRegex.FindStringSubmatch(line); m != nil {
+		return p.Theme.OC.Fallback.Render(m[1]) +
+			p.Theme.OC.Project.Render(m[2]) +
+			p.Theme.OC.Fallback.Render(m[3]) +
+			p.Theme.OC.URL.Render(m[4]) +
+			p.Theme.OC.Fallback.Render(m[5])
+	}
+	return p.renderWords(line)
+}
+
+// renderWords colors the resources, URLs, and deployment states in the line,
+// and the rest with [config.ThemeOC.Fallback].
+func (p *ProjectStatusPrinter) renderWords(line string) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range ocWordRegex.FindAllStringIndex(line, -1) {
+		// keep the parentheses around e.g "(svc/frontend)" uncolored
+		start, end := m[0], m[1]
+		if line[start] == '(' {
+			start++
+		}
+		if end > start && line[end-1] == ')' {
+			end--
+		}
+		c, ok := p.wordColor(line[start:end])
+		if !ok {
+			continue
+		}
+		sb.WriteString(p.Theme.OC.Fallback.Render(line[last:start]))
+		sb.WriteString(c.Render(line[start:end]))
+		last = end
+	}
+	sb.WriteString(p.Theme.OC.Fallback.Render(line[last:]))
+	return sb.String()
+}
+
+func (p *ProjectStatusPrinter) wordColor(word string) (color.Color, bool) {
+	switch {
+	case strings.HasPrefix(word, "http://") || strings.HasPrefix(word, "https://"):
+		return p.Theme.OC.URL, true
+	case ocResourceRegex.MatchString(word):
+		return p.Theme.OC.Resource, true
+	case word == "running":
+		return p.Theme.OC.Running, true
+	case word == "deployed":
+		return p.Theme.OC.Deployed, true
+	case word == "failed":
+		return p.Theme.OC.Failed, true
+	}
+	return color.Color{}, false
+}
@@ -0,0 +1,85 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestProjectPrinter(t *testing.T) {
+	theme := &config.Theme{
+		OC: config.ThemeOC{
+			Project: color.MustParse("magenta"),
+			Current: color.MustParse("green"),
+			URL:     color.MustParse("cyan"),
+		},
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "current project",
+			input: `Using project "foo" on server "https://api:6443".`,
+			want:  `Using project "\x1b[35mfoo\x1b[0m" on server "\x1b[36mhttps://api:6443\x1b[0m".`,
+		},
+		{
+			name:  "current in list",
+			input: "  * my-project",
+			want:  `  \x1b[32m* my-project\x1b[0m`,
+		},
+		{
+			name:  "other in list",
+			input: "    default",
+			want:  `    \x1b[35mdefault\x1b[0m`,
+		},
+		{
+			name:  "example command",
+			input: "    oc new-app rails-postgresql-example",
+			want:  "    oc new-app rails-postgresql-example",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var outBuf bytes.Buffer
+			printer := ProjectPrinter{Theme: theme}
+			printer.Print(strings.NewReader(tt.input+"\n"), &outBuf)
+			testutil.Equal(t, strings.ReplaceAll(tt.want, `\x1b`, "\x1b")+"\n", outBuf.String())
+		})
+	}
+}
+
+func TestProjectStatusPrinter_tree(t *testing.T) {
+	theme := &config.Theme{}
+	printer := ProjectStatusPrinter{Theme: theme}
+
+	input := testutil.NewHereDoc(`
+		svc/frontend - 172.30.1.2:8080
+		  dc/frontend deploys istag/frontend:latest
+		    deployment #2 running for 5 minutes
+		    deployment #1 deployed 2 hours ago
+		  dc/worker deploys istag/worker:latest
+
+		Errors:
+		  * pod/frontend-1-deploy has restarted 3 times
+	`)
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	want := testutil.NewHereDoc(`
+		svc/frontend - 172.30.1.2:8080
+		├─ dc/frontend deploys istag/frontend:latest
+		│  ├─ deployment #2 running for 5 minutes
+		│  └─ deployment #1 deployed 2 hours ago
+		└─ dc/worker deploys istag/worker:latest
+
+		Errors:
+		  * pod/frontend-1-deploy has restarted 3 times
+	`)
+	testutil.Equal(t, want, outBuf.String())
+}
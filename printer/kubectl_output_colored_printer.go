@@ -1,6 +1,7 @@
 package printer
 
 import (
+	"fmt"
 	"io"
 	"strings"
 
@@ -19,8 +20,32 @@ type KubectlOutputColoredPrinter struct {
 	SubcommandInfo    *kubectl.SubcommandInfo
 	Recursive         bool
 	ObjFreshThreshold config.DurationSlice
-	Theme             *config.Theme
-	KubecolorVersion  string
+	// Allocation holds the thresholds for the allocated resources in "kubectl describe node".
+	Allocation       config.Allocation
+	Theme            *config.Theme
+	KubecolorVersion string
+	Fold             config.Fold
+	Redactor         *Redactor
+	DecodeSecrets    bool
+	RowRules         []config.RowRule
+	// Summary counts statuses and verbs, to be printed after the output.
+	// It's not used in watch mode, as the same rows are printed many times.
+	Summary *Summary
+	// Identity colors namespaces, nodes, and resource kinds by their name, or nil if disabled.
+	Identity *IdentityColors
+	// ExplainTree draws tree guides in "kubectl explain --recursive" output.
+	ExplainTree bool
+	// RolloutProgress draws an in-place progress bar in "kubectl rollout status".
+	// Must only be set when the output is a terminal.
+	RolloutProgress bool
+	// Terminal is true when the output is a terminal, which allows
+	// updating lines in-place, e.g a live counter in "kubectl port-forward".
+	Terminal bool
+	// ProtectedContexts are glob patterns of context names to highlight
+	// in "kubectl config" output, e.g "prod-*".
+	ProtectedContexts []string
+	// Verbs are extra verbs to color, added to the built-in verbs of each subcommand.
+	Verbs []config.VerbRule
 }
 
 // ensures it implements the interface
@@ -32,6 +57,12 @@ func (p *KubectlOutputColoredPrinter) Print(r io.Reader, w io.Writer) {
 	printer.Print(r, w)
 }
 
+// PrinterType returns the type name of the printer used for the subcommand,
+// e.g "*printer.TablePrinter".
+func (p *KubectlOutputColoredPrinter) PrinterType() string {
+	return fmt.Sprintf("%T", p.getPrinter())
+}
+
 func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 	withHeader := !p.SubcommandInfo.NoHeader
 
@@ -47,19 +78,22 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 		return NewTablePrinter(false, p.Theme, nil) // api-versions always doesn't have header
 
 	case kubectl.Logs:
-		return &LogsPrinter{Theme: p.Theme}
+		return &LogsPrinter{Theme: p.Theme, Redactor: p.Redactor, Identity: p.Identity}
 
 	case kubectl.Get, kubectl.Events:
+		if p.SubcommandInfo.Raw {
+			return &RawPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
+		}
 		switch p.SubcommandInfo.Output {
 		case kubectl.OutputNone,
 			kubectl.OutputWide,
 			kubectl.OutputCustomColumns,
 			kubectl.OutputCustomColumnsFile:
-			// Age-based coloring only applies to "kubectl get". Other table
-			// subcommands (e.g. "kubectl events") have age-ish columns like
-			// "43s (x150 over 21h)" that would color inconsistently.
+			// Age-based coloring only applies to "kubectl get". The events
+			// tables have age-ish columns like "43s (x150 over 21h)",
+			// which are instead colored by the [EventsPrinter].
 			colorAge := p.SubcommandInfo.Subcommand == kubectl.Get
-			return NewTablePrinter(
+			tablePrinter := NewTablePrinter(
 				withHeader,
 				p.Theme,
 				func(_ int, column string) string {
@@ -71,14 +105,7 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 
 					// When Readiness is "n/m" then yellow
 					if left, right, ok := stringutil.ParseRatio(strings.TrimPrefix(column, "Init:")); ok {
-						switch {
-						case left == "0" && right == "0":
-							return p.Theme.Data.Ratio.Zero.Render(column)
-						case left == right:
-							return p.Theme.Data.Ratio.Equal.Render(column)
-						default:
-							return p.Theme.Data.Ratio.Unequal.Render(column)
-						}
+						return ColorRatio(left, right, p.Theme).Render(column)
 					}
 
 					// Object age: color by which fresh threshold it falls under
@@ -91,19 +118,34 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 					return column
 				},
 			)
+			tablePrinter.RowRules = p.RowRules
+			tablePrinter.Identity = p.Identity
+			if !p.SubcommandInfo.Watch {
+				tablePrinter.Summary = p.Summary
+			}
+			if p.SubcommandInfo.Subcommand == kubectl.Events || p.SubcommandInfo.Events {
+				return &EventsPrinter{
+					TablePrinter:      tablePrinter,
+					Theme:             p.Theme,
+					ObjFreshThreshold: p.ObjFreshThreshold,
+				}
+			}
+			return tablePrinter
 
 		case kubectl.OutputJSON:
-			return &JSONPrinter{Theme: p.Theme}
+			return &JSONPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
 
 		case kubectl.OutputYAML:
-			return &YAMLPrinter{Theme: p.Theme}
+			return &YAMLPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
 
 		default:
-			return &LogsPrinter{Theme: p.Theme}
+			return &LogsPrinter{Theme: p.Theme, Redactor: p.Redactor, Identity: p.Identity}
 		}
 
 	case kubectl.Describe:
 		return &DescribePrinter{
+			Redactor:   p.Redactor,
+			Allocation: p.Allocation,
 			TablePrinter: NewTablePrinter(false, p.Theme, func(_ int, column string) string {
 				if colored, ok := ColorStatus(column, p.Theme); ok {
 					return colored
@@ -116,6 +158,7 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 		return &ExplainPrinter{
 			Theme:     p.Theme,
 			Recursive: p.Recursive,
+			Tree:      p.ExplainTree,
 		}
 
 	case kubectl.Version:
@@ -139,43 +182,87 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 	case kubectl.Diff:
 		return &DiffPrinter{Theme: p.Theme}
 
-	case
-		kubectl.Apply,
-		kubectl.Create,
-		kubectl.Delete,
-		kubectl.Drain,
-		kubectl.Expose,
-		kubectl.Patch,
-		kubectl.Rollout,
-		kubectl.Scale,
-		kubectl.Uncordon,
-		kubectl.Annotate,
-		kubectl.Label:
+	case kubectl.PortForward, kubectl.Proxy:
+		return &PortForwardPrinter{Theme: p.Theme, Live: p.Terminal}
+
+	case kubectl.Config:
+		switch {
+		case p.SubcommandInfo.ConfigAction == "view" && p.SubcommandInfo.Output == kubectl.OutputJSON:
+			return &JSONPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: newKubeconfigRedactor(p.Redactor, p.Theme)}
+		case p.SubcommandInfo.ConfigAction == "view" && p.SubcommandInfo.Output != kubectl.OutputOther:
+			return &YAMLPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: newKubeconfigRedactor(p.Redactor, p.Theme)}
+		case p.SubcommandInfo.Output != kubectl.OutputNone:
+			// Other output formats, e.g "kubectl config get-contexts -o name",
+			// aren't tables, so they're colored by the verb rules below
+			break
+		case p.SubcommandInfo.ConfigAction == "get-contexts":
+			return newContextsPrinter(withHeader, p.Theme, p.ProtectedContexts)
+		case p.SubcommandInfo.ConfigAction == "get-clusters", p.SubcommandInfo.ConfigAction == "get-users":
+			return NewTablePrinter(withHeader, p.Theme, nil)
+		case p.SubcommandInfo.ConfigAction == "current-context":
+			return &CurrentContextPrinter{Theme: p.Theme, ProtectedContexts: p.ProtectedContexts}
+		}
+
+	case kubectl.Auth:
+		switch {
+		case p.SubcommandInfo.Output != kubectl.OutputNone:
+			// e.g "kubectl auth whoami -o yaml", colored by the verb rules below
+			break
+		case p.SubcommandInfo.AuthCanI && p.SubcommandInfo.List:
+			return newCanIListPrinter(p.Theme)
+		case p.SubcommandInfo.AuthCanI:
+			return &CanIPrinter{Theme: p.Theme}
+		case p.SubcommandInfo.AuthWhoAmI:
+			return newWhoAmIPrinter(p.Theme)
+		}
+
+	case kubectl.Rollout:
+		if p.SubcommandInfo.RolloutStatus && p.SubcommandInfo.Output == kubectl.OutputNone {
+			return &RolloutStatusPrinter{Theme: p.Theme, Progress: p.RolloutProgress}
+		}
+
+	// oc (OpenShift CLI) specific subcommands
+	case kubectl.Project, kubectl.Projects, kubectl.NewProject:
+		if p.SubcommandInfo.Output == kubectl.OutputNone {
+			return &ProjectPrinter{Theme: p.Theme}
+		}
+
+	case kubectl.Status:
+		if p.SubcommandInfo.Output == kubectl.OutputNone {
+			return &ProjectStatusPrinter{Theme: p.Theme}
+		}
+
+	case kubectl.WhoAmI:
+		return &SingleColoredPrinter{Color: p.Theme.OC.User}
+
+	case kubectl.Adm:
+		if p.SubcommandInfo.AdmAction == "top" {
+			return NewTablePrinter(withHeader, p.Theme, nil)
+		}
+
+	}
+
+	if hasVerbRules(p.SubcommandInfo.Subcommand, defaultVerbRules, p.Verbs) {
 		switch p.SubcommandInfo.Output {
 		case kubectl.OutputJSON:
-			return &JSONPrinter{Theme: p.Theme}
+			return &JSONPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
 		case kubectl.OutputYAML:
-			return &YAMLPrinter{Theme: p.Theme}
+			return &YAMLPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
 		}
-		switch p.SubcommandInfo.Subcommand {
-		case kubectl.Apply:
+		if p.SubcommandInfo.Subcommand == kubectl.Apply {
 			switch {
 			case p.SubcommandInfo.EditLastApplied:
 				// if running "kubectl apply edit-last-applied --help"
 				// then our "HelpPrinter" branch above should've caught that
 				panic("coloring not supported")
 			case p.SubcommandInfo.ViewLastApplied:
-				switch p.SubcommandInfo.Output {
-				case kubectl.OutputJSON:
-					return &JSONPrinter{Theme: p.Theme}
-				default:
-					return &YAMLPrinter{Theme: p.Theme}
-				}
-
+				return &YAMLPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}
 			case p.SubcommandInfo.SetLastApplied:
 				return &VerbPrinter{
 					DryRunColor:   p.Theme.Apply.DryRun,
 					FallbackColor: p.Theme.Apply.Fallback,
+					Summary:       p.Summary,
+					Identity:      p.Identity,
 					VerbColor: map[string]color.Color{
 						"configured":           p.Theme.Apply.Configured,
 						"no changes required.": p.Theme.Apply.Unchanged,
@@ -185,118 +272,11 @@ func (p *KubectlOutputColoredPrinter) getPrinter() Printer {
 					},
 				}
 			}
-
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Apply.DryRun,
-				FallbackColor: p.Theme.Apply.Fallback,
-				VerbColor: map[string]color.Color{
-					"created":            p.Theme.Apply.Created,
-					"configured":         p.Theme.Apply.Configured,
-					"unchanged":          p.Theme.Apply.Unchanged,
-					"serverside-applied": p.Theme.Apply.Serverside,
-				},
-			}
-
-		case kubectl.Create:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Create.DryRun,
-				FallbackColor: p.Theme.Create.Fallback,
-				VerbColor: map[string]color.Color{
-					"created": p.Theme.Create.Created,
-				},
-			}
-
-		case kubectl.Delete:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Delete.DryRun,
-				FallbackColor: p.Theme.Delete.Fallback,
-				VerbColor: map[string]color.Color{
-					"deleted": p.Theme.Delete.Deleted,
-				},
-			}
-
-		case kubectl.Expose:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Expose.DryRun,
-				FallbackColor: p.Theme.Expose.Fallback,
-				VerbColor: map[string]color.Color{
-					"exposed": p.Theme.Expose.Exposed,
-				},
-			}
-
-		case kubectl.Patch:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Patch.DryRun,
-				FallbackColor: p.Theme.Patch.Fallback,
-				VerbColor: map[string]color.Color{
-					"patched": p.Theme.Patch.Patched,
-				},
-			}
-
-		case kubectl.Scale:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Scale.DryRun,
-				FallbackColor: p.Theme.Scale.Fallback,
-				VerbColor: map[string]color.Color{
-					"scaled": p.Theme.Scale.Scaled,
-				},
-			}
-
-		case kubectl.Rollout:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Rollout.DryRun,
-				FallbackColor: p.Theme.Rollout.Fallback,
-				VerbColor: map[string]color.Color{
-					"rolled back": p.Theme.Rollout.RolledBack,
-					"paused":      p.Theme.Rollout.Paused,
-					"resumed":     p.Theme.Rollout.Resumed,
-					"restarted":   p.Theme.Rollout.Restarted,
-				},
-			}
-
-		case kubectl.Drain:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Drain.DryRun,
-				FallbackColor: p.Theme.Drain.Fallback,
-				VerbColor: map[string]color.Color{
-					"cordoned": p.Theme.Drain.Cordoned,
-					"evicted":  p.Theme.Drain.Evicted,
-					"drained":  p.Theme.Drain.Drained,
-				},
-				PrefixVerbColor: map[string]color.Color{
-					"evicting pod": p.Theme.Drain.EvictingPod,
-				},
-			}
-
-		case kubectl.Uncordon:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Uncordon.DryRun,
-				FallbackColor: p.Theme.Uncordon.Fallback,
-				VerbColor: map[string]color.Color{
-					"uncordoned": p.Theme.Uncordon.Uncordoned,
-				},
-			}
-
-		case kubectl.Annotate:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Annotate.DryRun,
-				FallbackColor: p.Theme.Annotate.Fallback,
-				VerbColor: map[string]color.Color{
-					"annotated": p.Theme.Annotate.Annotated,
-				},
-			}
-
-		case kubectl.Label:
-			return &VerbPrinter{
-				DryRunColor:   p.Theme.Label.DryRun,
-				FallbackColor: p.Theme.Label.Fallback,
-				VerbColor: map[string]color.Color{
-					"unlabeled":   p.Theme.Label.Unlabeled,
-					"labeled":     p.Theme.Label.Labeled,
-					"not labeled": p.Theme.Label.NotLabeled,
-				},
-			}
 		}
+		verbPrinter := newVerbPrinter(p.SubcommandInfo.Subcommand, p.Theme, p.Verbs)
+		verbPrinter.Summary = p.Summary
+		verbPrinter.Identity = p.Identity
+		return verbPrinter
 	}
 
 	return &SingleColoredPrinter{Color: p.Theme.Default}
@@ -2,6 +2,7 @@ package printer
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -11,8 +12,8 @@ import (
 )
 
 // Age-based coloring must apply only to "kubectl get" tables, not to other
-// table subcommands like "kubectl events" (whose age-ish columns, e.g.
-// "43s (x150 over 21h)", would color inconsistently).
+// tables from subcommands like "kubectl events". Their age-ish columns, e.g.
+// "43s (x150 over 21h)", are only colored in events tables by the EventsPrinter.
 func Test_KubectlOutputColoredPrinter_ageColorScopedToGet(t *testing.T) {
 	theme := &config.Theme{
 		Table: config.ThemeTable{
@@ -48,3 +49,69 @@ func Test_KubectlOutputColoredPrinter_ageColorScopedToGet(t *testing.T) {
 		t.Errorf("events: expected age NOT fresh-colored, got %q", events)
 	}
 }
+
+func Test_KubectlOutputColoredPrinter_verbRules(t *testing.T) {
+	theme := &config.Theme{
+		Taint: config.ThemeTaint{
+			Tainted:  color.MustParse("red"),
+			Fallback: color.MustParse("blue"),
+		},
+	}
+
+	render := func(sub kubectl.Subcommand, verbs []config.VerbRule, input string) string {
+		p := &KubectlOutputColoredPrinter{
+			SubcommandInfo: &kubectl.SubcommandInfo{Subcommand: sub},
+			Theme:          theme,
+			Verbs:          verbs,
+		}
+		var buf bytes.Buffer
+		p.Print(strings.NewReader(input), &buf)
+		return buf.String()
+	}
+
+	if got, want := render(kubectl.Taint, nil, "node/foo tainted\n"), "node/foo \x1b[31mtainted\x1b[0m\n"; got != want {
+		t.Errorf("built-in verb:\nwant %q\ngot  %q", want, got)
+	}
+	if got, want := render(kubectl.Taint, nil, "error: something\n"), "\x1b[34merror: something\x1b[0m\n"; got != want {
+		t.Errorf("fallback from theme.taint.fallback:\nwant %q\ngot  %q", want, got)
+	}
+
+	verbs := []config.VerbRule{
+		{Subcommand: "taint", Verb: "tainted", Color: "green"},
+		{Subcommand: "debug", Verb: "condition met", Color: "theme.taint.tainted"},
+	}
+	if got, want := render(kubectl.Taint, verbs, "node/foo tainted\n"), "node/foo \x1b[32mtainted\x1b[0m\n"; got != want {
+		t.Errorf("user rule overrides built-in verb:\nwant %q\ngot  %q", want, got)
+	}
+	if got, want := render(kubectl.Debug, verbs, "pod/foo condition met\n"), "pod/foo \x1b[31mcondition met\x1b[0m\n"; got != want {
+		t.Errorf("user rule adds subcommand:\nwant %q\ngot  %q", want, got)
+	}
+}
+
+func Test_KubectlOutputColoredPrinter_getPrinter(t *testing.T) {
+	tests := []struct {
+		name string
+		info kubectl.SubcommandInfo
+		want string
+	}{
+		{"port-forward", kubectl.SubcommandInfo{Subcommand: kubectl.PortForward}, "*printer.PortForwardPrinter"},
+		{"proxy", kubectl.SubcommandInfo{Subcommand: kubectl.Proxy}, "*printer.PortForwardPrinter"},
+		{"config view", kubectl.SubcommandInfo{Subcommand: kubectl.Config, ConfigAction: "view"}, "*printer.YAMLPrinter"},
+		{"config view -o json", kubectl.SubcommandInfo{Subcommand: kubectl.Config, ConfigAction: "view", Output: kubectl.OutputJSON}, "*printer.JSONPrinter"},
+		{"config get-contexts", kubectl.SubcommandInfo{Subcommand: kubectl.Config, ConfigAction: "get-contexts"}, "*printer.TablePrinter"},
+		{"config get-contexts -o name", kubectl.SubcommandInfo{Subcommand: kubectl.Config, ConfigAction: "get-contexts", Output: kubectl.OutputOther}, "*printer.VerbPrinter"},
+		{"config current-context", kubectl.SubcommandInfo{Subcommand: kubectl.Config, ConfigAction: "current-context"}, "*printer.CurrentContextPrinter"},
+		{"auth can-i", kubectl.SubcommandInfo{Subcommand: kubectl.Auth, AuthCanI: true}, "*printer.CanIPrinter"},
+		{"auth whoami -o yaml", kubectl.SubcommandInfo{Subcommand: kubectl.Auth, AuthWhoAmI: true, Output: kubectl.OutputYAML}, "*printer.YAMLPrinter"},
+		{"rollout status", kubectl.SubcommandInfo{Subcommand: kubectl.Rollout, RolloutStatus: true}, "*printer.RolloutStatusPrinter"},
+		{"rollout restart", kubectl.SubcommandInfo{Subcommand: kubectl.Rollout}, "*printer.VerbPrinter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &KubectlOutputColoredPrinter{SubcommandInfo: &tt.info, Theme: &config.Theme{}}
+			if got := fmt.Sprintf("%T", p.getPrinter()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// PortForwardPrinter is used in "kubectl port-forward" and "kubectl proxy" output:
+//
+//	$ kubectl port-forward svc/foo 8080:80
+//	Forwarding from 127.0.0.1:8080 -> 80
+//	Forwarding from [::1]:8080 -> 80
+//	Handling connection for 8080
+//	Handling connection for 8080
+//
+//	$ kubectl proxy
+//	Starting to serve on 127.0.0.1:8001
+type PortForwardPrinter struct {
+	Theme *config.Theme
+	// Live collapses repeated "Handling connection for 8080" lines into
+	// a single line with a counter that is updated in-place.
+	// Must only be used when the output is a terminal.
+	Live bool
+}
+
+// ensures it implements the interface
+var _ Printer = &PortForwardPrinter{}
+
+var (
+	// portForwardRegex matches e.g "Forwarding from 127.0.0.1:8080 -> 80"
+	portForwardRegex = regexp.MustCompile(`^(Forwarding from )(\S+)( -> )(\S+)$`)
+	// proxyServeRegex matches e.g "Starting to serve on 127.0.0.1:8001"
+	proxyServeRegex = regexp.MustCompile(`^(Starting to serve on )(\S+)$`)
+)
+
+const portForwardConnectionPrefix = "Handling connection for "
+
+// Print implements [Printer.Print]
+func (p *PortForwardPrinter) Print(r io.Reader, w io.Writer) {
+	// lastConnection is the "Handling connection" line printed in-place,
+	// and connections is how many times it has been repeated.
+	var lastConnection string
+	var connections int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if p.Live && strings.HasPrefix(line, portForwardConnectionPrefix) {
+			if line == lastConnection {
+				connections++
+				fmt.Fprint(w, clearLine)
+			} else {
+				if lastConnection != "" {
+					fmt.Fprintln(w)
+				}
+				lastConnection, connections = line, 1
+			}
+			fmt.Fprint(w, p.Theme.PortForward.Connection.Render(fmt.Sprintf("%s (x%d)", line, connections)))
+			continue
+		}
+		if lastConnection != "" {
+			fmt.Fprintln(w)
+			lastConnection = ""
+		}
+
+		fmt.Fprintln(w, p.colorLine(line))
+	}
+	if lastConnection != "" {
+		fmt.Fprintln(w)
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print port-forward output.", "error", err)
+	}
+}
+
+func (p *PortForwardPrinter) colorLine(line string) string {
+	if m := portForwardRegex.FindStringSubmatch(line); m != nil {
+		return m[1] + p.Theme.PortForward.Local.Render(m[2]) + m[3] + p.Theme.PortForward.Remote.Render(m[4])
+	}
+	if m := proxyServeRegex.FindStringSubmatch(line); m != nil {
+		return m[1] + p.Theme.PortForward.Local.Render(m[2])
+	}
+	switch {
+	case strings.HasPrefix(line, portForwardConnectionPrefix):
+		return p.Theme.PortForward.Connection.Render(line)
+	case strings.Contains(line, "error occurred forwarding"),
+		strings.HasPrefix(line, "error:"),
+		strings.HasPrefix(line, "Unable to listen on"):
+		return p.Theme.PortForward.Error.Render(line)
+	default:
+		return p.Theme.PortForward.Fallback.Render(line)
+	}
+}
@@ -0,0 +1,53 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/kubecolor/kubecolor/config/testconfig"
+	"github.com/kubecolor/kubecolor/internal/linemerge"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestPortForwardPrinter_fail(t *testing.T) {
+	var logBuf bytes.Buffer
+	testutil.SetTestLogger(t, &logBuf)
+
+	var outBuf bytes.Buffer
+	printer := PortForwardPrinter{Theme: testconfig.NullTheme}
+	printer.Print(testutil.DummyReader{ReadFunc: func(b []byte) (int, error) { return 0, errors.New("test") }}, &outBuf)
+
+	testutil.Equal(t, "", outBuf.String(), "output")
+	testutil.Equal(t, "level=ERROR msg=\"Failed to print port-forward output.\" error=test\n", logBuf.String(), "logs")
+}
+
+func TestPortForwardPrinter_liveWithLineMerge(t *testing.T) {
+	out := make(chan string, 10)
+	pr, pw := io.Pipe()
+	m := linemerge.New()
+	m.Delay = 10 * time.Millisecond
+	s := m.NewStream(pr, writerFunc(func(b []byte) (int, error) {
+		out <- string(b)
+		return len(b), nil
+	}))
+	go func() {
+		defer s.Close()
+		printer := PortForwardPrinter{Theme: testconfig.NullTheme, Live: true}
+		printer.Print(s, s)
+	}()
+
+	// The counter has no newline, but must still be shown while port-forward is running
+	go pw.Write([]byte("Handling connection for 8080\n"))
+	select {
+	case got := <-out:
+		testutil.Equal(t, "Handling connection for 8080 (x1)", got)
+	case <-time.After(time.Second):
+		t.Fatal("counter was not written")
+	}
+
+	pw.Close()
+	m.Wait()
+}
@@ -0,0 +1,145 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// RawPrinter is used in "kubectl get --raw" output, where the format
+// depends on the endpoint, so it's sniffed from the first line:
+//
+//   - JSON, e.g from "/api/v1/namespaces", uses the [JSONPrinter]
+//   - Prometheus metrics, e.g from "/metrics"
+//   - Health checks, e.g from "/readyz?verbose"
+type RawPrinter struct {
+	Theme         *config.Theme
+	Fold          config.Fold
+	Redactor      *Redactor
+	DecodeSecrets bool
+}
+
+// ensures it implements the interface
+var _ Printer = &RawPrinter{}
+
+type rawFormat byte
+
+const (
+	rawFormatOther rawFormat = iota
+	rawFormatJSON
+	rawFormatMetrics
+	rawFormatHealth
+)
+
+var (
+	// metricRegex matches a Prometheus sample, e.g `apiserver_request_total{code="200"} 42 1700000000000`
+	metricRegex = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{.*\})?(\s+)([+-]?(?:\d[\d.eE+-]*|\.\d[\d.eE+-]*|NaN|Inf))(\s+-?\d+)?$`)
+	// metricCommentRegex matches e.g "# HELP apiserver_request_total Counter of apiserver requests"
+	metricCommentRegex = regexp.MustCompile(`^(#\s+(?:HELP|TYPE)\s+)([a-zA-Z_:][a-zA-Z0-9_:]*)(.*)$`)
+	// metricLabelRegex matches a label in a sample, e.g `code="200"`
+	metricLabelRegex = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)(=)("(?:[^"\\]|\\.)*")`)
+)
+
+// Print implements [Printer.Print]
+func (p *RawPrinter) Print(r io.Reader, w io.Writer) {
+	br := bufio.NewReader(r)
+	firstLine, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		slog.Error("Failed to print raw output.", "error", err)
+		return
+	}
+	rest := io.MultiReader(strings.NewReader(firstLine), br)
+
+	switch sniffRawFormat(firstLine) {
+	case rawFormatJSON:
+		(&JSONPrinter{Theme: p.Theme, Fold: p.Fold, Redactor: p.Redactor, DecodeSecrets: p.DecodeSecrets}).Print(rest, w)
+	case rawFormatMetrics:
+		p.printLines(rest, w, p.colorMetricLine)
+	case rawFormatHealth:
+		p.printLines(rest, w, p.colorHealthLine)
+	default:
+		(&SingleColoredPrinter{Color: p.Theme.Raw.Fallback}).Print(rest, w)
+	}
+}
+
+// sniffRawFormat returns the format of the output, based on its first line.
+func sniffRawFormat(firstLine string) rawFormat {
+	line := strings.TrimSpace(firstLine)
+	switch {
+	case strings.HasPrefix(line, "[+]"), strings.HasPrefix(line, "[-]"),
+		line == "ok", strings.HasSuffix(line, "check passed"), strings.HasSuffix(line, "check failed"):
+		return rawFormatHealth
+	case strings.HasPrefix(line, "{"), strings.HasPrefix(line, "["):
+		return rawFormatJSON
+	case metricCommentRegex.MatchString(line), metricRegex.MatchString(line):
+		return rawFormatMetrics
+	default:
+		return rawFormatOther
+	}
+}
+
+func (p *RawPrinter) printLines(r io.Reader, w io.Writer, colorLine func(string) string) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		fmt.Fprintln(w, colorLine(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print raw output.", "error", err)
+	}
+}
+
+// colorMetricLine colors a line of Prometheus metrics, e.g:
+//
+//	# HELP apiserver_request_total Counter of apiserver requests
+//	# TYPE apiserver_request_total counter
+//	apiserver_request_total{code="200",verb="GET"} 42
+func (p *RawPrinter) colorMetricLine(line string) string {
+	if m := metricCommentRegex.FindStringSubmatch(line); m != nil {
+		rest := p.Theme.Raw.Comment.Render(m[3])
+		if strings.Contains(m[1], "TYPE") {
+			rest = p.Theme.Raw.Type.Render(m[3])
+		}
+		return p.Theme.Raw.Comment.Render(m[1]) + p.Theme.Raw.Metric.Render(m[2]) + rest
+	}
+	if strings.HasPrefix(line, "#") {
+		return p.Theme.Raw.Comment.Render(line)
+	}
+	m := metricRegex.FindStringSubmatch(line)
+	if m == nil {
+		return line
+	}
+	var sb strings.Builder
+	sb.WriteString(p.Theme.Raw.Metric.Render(m[1]))
+	sb.WriteString(metricLabelRegex.ReplaceAllStringFunc(m[2], func(label string) string {
+		lm := metricLabelRegex.FindStringSubmatch(label)
+		return p.Theme.Raw.LabelKey.Render(lm[1]) + lm[2] + p.Theme.Raw.LabelValue.Render(lm[3])
+	}))
+	sb.WriteString(m[3])
+	sb.WriteString(p.Theme.Raw.Value.Render(m[4]))
+	sb.WriteString(m[5])
+	return sb.String()
+}
+
+// colorHealthLine colors a line of health checks, e.g:
+//
+//	[+]ping ok
+//	[-]etcd failed: reason withheld
+//	readyz check failed
+func (p *RawPrinter) colorHealthLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "[+]"), trimmed == "ok", strings.HasSuffix(trimmed, "check passed"):
+		return p.Theme.Raw.HealthOK.Render(line)
+	case strings.HasPrefix(trimmed, "[-]"), strings.HasSuffix(trimmed, "check failed"):
+		return p.Theme.Raw.HealthFailed.Render(line)
+	default:
+		return p.Theme.Raw.Fallback.Render(line)
+	}
+}
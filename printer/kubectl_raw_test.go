@@ -0,0 +1,30 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestSniffRawFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want rawFormat
+	}{
+		{name: "json object", line: `{"kind":"APIVersions","versions":["v1"]}`, want: rawFormatJSON},
+		{name: "json array", line: "[\n", want: rawFormatJSON},
+		{name: "metrics help", line: "# HELP apiserver_request_total Counter of apiserver requests\n", want: rawFormatMetrics},
+		{name: "metrics sample", line: `apiserver_request_total{code="200"} 42`, want: rawFormatMetrics},
+		{name: "health passed check", line: "[+]ping ok\n", want: rawFormatHealth},
+		{name: "health failed check", line: "[-]etcd failed: reason withheld\n", want: rawFormatHealth},
+		{name: "health ok", line: "ok", want: rawFormatHealth},
+		{name: "other", line: "<html>", want: rawFormatOther},
+		{name: "text", line: "some plain text", want: rawFormatOther},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			testutil.Equal(t, tc.want, sniffRawFormat(tc.line))
+		})
+	}
+}
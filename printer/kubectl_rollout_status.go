@@ -0,0 +1,125 @@
+package printer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/internal/bytesutil"
+)
+
+// RolloutStatusPrinter is used in "kubectl rollout status" output:
+//
+//	$ kubectl rollout status deployment/foo
+//	Waiting for deployment "foo" rollout to finish: 1 of 3 updated replicas are available...
+//	Waiting for deployment "foo" rollout to finish: 2 of 3 updated replicas are available...
+//	deployment "foo" successfully rolled out
+type RolloutStatusPrinter struct {
+	Theme *config.Theme
+	// Progress draws the "Waiting for ..." lines in-place with a progress bar,
+	// instead of printing a new line for each of them.
+	// Must only be used when the output is a terminal.
+	Progress bool
+}
+
+// ensures it implements the interface
+var _ Printer = &RolloutStatusPrinter{}
+
+// rolloutRatioRegex matches the counts in the rollout progress, e.g
+// "1 of 3" in "1 of 3 updated replicas are available..." and
+// "2 out of 3" in "2 out of 3 new replicas have been updated..."
+var rolloutRatioRegex = regexp.MustCompile(`\b(\d+) (?:out )?of (\d+)\b`)
+
+// rolloutProgressWidth is the number of cells in the progress bar, e.g "███░░░"
+const rolloutProgressWidth = 20
+
+// clearLine moves the cursor to the start of the line and erases the line
+const clearLine = "\r\x1b[2K"
+
+// Print implements [Printer.Print]
+func (p *RolloutStatusPrinter) Print(r io.Reader, w io.Writer) {
+	// inPlace is true when the last printed line is a progress bar without a newline
+	inPlace := false
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, bytesutil.MaxLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inPlace {
+			fmt.Fprint(w, clearLine)
+			inPlace = false
+		}
+
+		switch {
+		case isRolloutDone(line):
+			fmt.Fprintln(w, p.Theme.Rollout.RolledOut.Render(line))
+
+		case strings.HasPrefix(line, "error:"):
+			fmt.Fprintln(w, p.Theme.Rollout.Failed.Render(line))
+
+		case strings.HasPrefix(line, "Waiting for "):
+			colored, left, right, ok := p.colorRatio(line)
+			if ok && p.Progress {
+				fmt.Fprint(w, p.progressBar(left, right), " ", colored)
+				inPlace = true
+			} else {
+				fmt.Fprintln(w, colored)
+			}
+
+		default:
+			fmt.Fprintln(w, p.Theme.Rollout.Fallback.Render(line))
+		}
+	}
+	if inPlace {
+		fmt.Fprintln(w)
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print rollout status output.", "error", err)
+	}
+}
+
+// isRolloutDone returns true on the lines printed when the rollout has finished, e.g
+// "deployment "foo" successfully rolled out" or "statefulset rolling update complete 3 pods at revision foo-5b7d..."
+func isRolloutDone(line string) bool {
+	return strings.HasSuffix(line, "successfully rolled out") ||
+		strings.HasPrefix(line, "partitioned roll out complete") ||
+		strings.HasPrefix(line, "statefulset rolling update complete")
+}
+
+// colorRatio colors the counts of the rollout, e.g "1 of 3", and the rest of the line
+// with the waiting color. Returns false if the line has no counts.
+func (p *RolloutStatusPrinter) colorRatio(line string) (string, int, int, bool) {
+	loc := rolloutRatioRegex.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return p.Theme.Rollout.Waiting.Render(line), 0, 0, false
+	}
+	leftText, rightText := line[loc[2]:loc[3]], line[loc[4]:loc[5]]
+	left, errLeft := strconv.Atoi(leftText)
+	right, errRight := strconv.Atoi(rightText)
+	if errLeft != nil || errRight != nil {
+		return p.Theme.Rollout.Waiting.Render(line), 0, 0, false
+	}
+	ratioColor := ColorRatio(leftText, rightText, p.Theme)
+	colored := p.Theme.Rollout.Waiting.Render(line[:loc[0]]) +
+		ratioColor.Render(line[loc[0]:loc[1]]) +
+		p.Theme.Rollout.Waiting.Render(line[loc[1]:])
+	return colored, left, right, true
+}
+
+// progressBar returns a bar filled by how far the rollout has come, e.g "██████░░░░░░"
+func (p *RolloutStatusPrinter) progressBar(left, right int) string {
+	filled := rolloutProgressWidth
+	if right > 0 {
+		filled = min(max(left, 0)*rolloutProgressWidth/right, rolloutProgressWidth)
+	}
+	ratioColor := ColorRatio(strconv.Itoa(left), strconv.Itoa(right), p.Theme)
+	bar := ratioColor.Render(strings.Repeat("█", filled))
+	if filled < rolloutProgressWidth {
+		bar += p.Theme.Rollout.Progress.Render(strings.Repeat("░", rolloutProgressWidth-filled))
+	}
+	return bar
+}
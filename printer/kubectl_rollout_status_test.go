@@ -0,0 +1,80 @@
+package printer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubecolor/kubecolor/config/testconfig"
+	"github.com/kubecolor/kubecolor/internal/linemerge"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestRolloutStatusPrinter_fail(t *testing.T) {
+	var logBuf bytes.Buffer
+	testutil.SetTestLogger(t, &logBuf)
+
+	var outBuf bytes.Buffer
+	printer := RolloutStatusPrinter{Theme: testconfig.NullTheme}
+	printer.Print(testutil.DummyReader{ReadFunc: func(b []byte) (int, error) { return 0, errors.New("test") }}, &outBuf)
+
+	testutil.Equal(t, "", outBuf.String(), "output")
+	testutil.Equal(t, "level=ERROR msg=\"Failed to print rollout status output.\" error=test\n", logBuf.String(), "logs")
+}
+
+func TestRolloutStatusPrinter_progress(t *testing.T) {
+	printer := RolloutStatusPrinter{Theme: testconfig.NullTheme, Progress: true}
+
+	input := testutil.NewHereDoc(`
+		Waiting for deployment "foo" rollout to finish: 1 of 4 updated replicas are available...
+		Waiting for deployment "foo" rollout to finish: 2 out of 4 new replicas have been updated...
+		deployment "foo" successfully rolled out
+	`)
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	want := "█████░░░░░░░░░░░░░░░ Waiting for deployment \"foo\" rollout to finish: 1 of 4 updated replicas are available..." +
+		clearLine + "██████████░░░░░░░░░░ Waiting for deployment \"foo\" rollout to finish: 2 out of 4 new replicas have been updated..." +
+		clearLine + "deployment \"foo\" successfully rolled out\n"
+	testutil.Equal(t, want, outBuf.String())
+}
+
+func TestRolloutStatusPrinter_progressAtEOF(t *testing.T) {
+	printer := RolloutStatusPrinter{Theme: testconfig.NullTheme, Progress: true}
+
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader("Waiting for daemon set \"foo\" rollout to finish: 0 of 0 updated pods are available...\n"), &outBuf)
+
+	testutil.Equal(t, "████████████████████ Waiting for daemon set \"foo\" rollout to finish: 0 of 0 updated pods are available...\n", outBuf.String())
+}
+
+func TestRolloutStatusPrinter_progressWithLineMerge(t *testing.T) {
+	out := make(chan string, 10)
+	pr, pw := io.Pipe()
+	m := linemerge.New()
+	m.Delay = 10 * time.Millisecond
+	s := m.NewStream(pr, writerFunc(func(b []byte) (int, error) {
+		out <- string(b)
+		return len(b), nil
+	}))
+	go func() {
+		defer s.Close()
+		printer := RolloutStatusPrinter{Theme: testconfig.NullTheme, Progress: true}
+		printer.Print(s, s)
+	}()
+
+	// The progress bar has no newline, but must still be shown while the rollout is running
+	go pw.Write([]byte("Waiting for deployment \"foo\" rollout to finish: 1 of 4 updated replicas are available...\n"))
+	select {
+	case got := <-out:
+		testutil.Equal(t, "█████░░░░░░░░░░░░░░░ Waiting for deployment \"foo\" rollout to finish: 1 of 4 updated replicas are available...", got)
+	case <-time.After(time.Second):
+		t.Fatal("progress bar was not written")
+	}
+
+	pw.Close()
+	m.Wait()
+}
@@ -25,7 +25,10 @@ import (
 // - kubectl drain
 // - kubectl expose
 // - kubectl patch
+// - kubectl taint
 // - kubectl uncordon
+//
+// The verbs of each subcommand are listed in [defaultVerbRules].
 type VerbPrinter struct {
 	// VerbColor is used for verbs at the end of the line (followed by optional "dry-run"), e.g:
 	// 	 pod/nginx-28729634-nh2vc evicted
@@ -41,6 +44,12 @@ type VerbPrinter struct {
 
 	// FallbackColor is used when no verbs has matched on the output line.
 	FallbackColor color.Color
+
+	// Summary counts the verbs, if enabled.
+	Summary *Summary
+
+	// Identity colors the kind prefix, e.g "deployment.apps/" in "deployment.apps/foo created", if enabled.
+	Identity *IdentityColors
 }
 
 // ensures it implements the interface
@@ -65,6 +74,7 @@ func (p *VerbPrinter) Print(r io.Reader, w io.Writer) {
 		colored, isColored := p.colorizeVerb(line)
 		if isColored {
 			colored = p.colorizeDryRun(colored)
+			colored, _ = p.Identity.RenderKindPrefix(colored)
 		} else {
 			colored = p.FallbackColor.Render(line)
 		}
@@ -105,6 +115,7 @@ func (p *VerbPrinter) colorizeVerb(line string) (string, bool) {
 	}
 
 	if anyMatch {
+		p.Summary.addVerb(match.Verb, match.Color)
 		line = fmt.Sprintf("%s%s%s", match.Before, match.Color.Render(match.Verb), match.After)
 	}
 
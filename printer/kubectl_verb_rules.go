@@ -0,0 +1,144 @@
+package printer
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/kubectl"
+)
+
+// defaultVerbRules are the verbs colored by the [VerbPrinter], per subcommand.
+// A subcommand with at least one rule gets verb coloring.
+//
+// The dry-run and fallback colors are looked up from the theme keys
+// "theme.<subcommand>.dryrun" and "theme.<subcommand>.fallback".
+var defaultVerbRules = []config.VerbRule{
+	{Subcommand: "adm", Verb: "added", Color: "theme.oc.added"},
+	{Subcommand: "adm", Verb: "removed", Color: "theme.oc.removed"},
+	{Subcommand: "annotate", Verb: "annotated", Color: "theme.annotate.annotated"},
+	{Subcommand: "apply", Verb: "created", Color: "theme.apply.created"},
+	{Subcommand: "apply", Verb: "configured", Color: "theme.apply.configured"},
+	{Subcommand: "apply", Verb: "unchanged", Color: "theme.apply.unchanged"},
+	{Subcommand: "apply", Verb: "serverside-applied", Color: "theme.apply.serverside"},
+	{Subcommand: "auth", Verb: "reconciled", Color: "theme.auth.reconciled"},
+	{Subcommand: "autoscale", Verb: "autoscaled", Color: "theme.autoscale.autoscaled"},
+	{Subcommand: "certificate", Verb: "approved", Color: "theme.certificate.approved"},
+	{Subcommand: "certificate", Verb: "denied", Color: "theme.certificate.denied"},
+	{Subcommand: "config", Verb: "Switched to context", Color: "theme.config.switched", Prefix: true},
+	{Subcommand: "config", Verb: "created", Color: "theme.config.created"},
+	{Subcommand: "config", Verb: "modified", Color: "theme.config.modified"},
+	{Subcommand: "config", Verb: "renamed to", Color: "theme.config.renamed"},
+	{Subcommand: "config", Verb: "set", Color: "theme.config.set"},
+	{Subcommand: "config", Verb: "unset", Color: "theme.config.unset"},
+	{Subcommand: "config", Verb: "deleted context", Color: "theme.config.deleted", Prefix: true},
+	{Subcommand: "config", Verb: "deleted cluster", Color: "theme.config.deleted", Prefix: true},
+	{Subcommand: "config", Verb: "deleted user", Color: "theme.config.deleted", Prefix: true},
+	{Subcommand: "cordon", Verb: "cordoned", Color: "theme.cordon.cordoned"},
+	{Subcommand: "create", Verb: "created", Color: "theme.create.created"},
+	{Subcommand: "delete", Verb: "deleted", Color: "theme.delete.deleted"},
+	{Subcommand: "drain", Verb: "cordoned", Color: "theme.drain.cordoned"},
+	{Subcommand: "drain", Verb: "evicted", Color: "theme.drain.evicted"},
+	{Subcommand: "drain", Verb: "drained", Color: "theme.drain.drained"},
+	{Subcommand: "drain", Verb: "evicting pod", Color: "theme.drain.evictingpod", Prefix: true},
+	{Subcommand: "expose", Verb: "exposed", Color: "theme.expose.exposed"},
+	{Subcommand: "label", Verb: "unlabeled", Color: "theme.label.unlabeled"},
+	{Subcommand: "label", Verb: "labeled", Color: "theme.label.labeled"},
+	{Subcommand: "label", Verb: "not labeled", Color: "theme.label.notlabeled"},
+	{Subcommand: "new-app", Verb: "-->", Color: "theme.oc.heading", Prefix: true},
+	{Subcommand: "new-app", Verb: "created", Color: "theme.oc.created"},
+	{Subcommand: "new-app", Verb: "Success", Color: "theme.oc.success"},
+	{Subcommand: "new-build", Verb: "-->", Color: "theme.oc.heading", Prefix: true},
+	{Subcommand: "new-build", Verb: "created", Color: "theme.oc.created"},
+	{Subcommand: "new-build", Verb: "Success", Color: "theme.oc.success"},
+	{Subcommand: "patch", Verb: "patched", Color: "theme.patch.patched"},
+	{Subcommand: "replace", Verb: "replaced", Color: "theme.replace.replaced"},
+	{Subcommand: "rollout", Verb: "rolled back", Color: "theme.rollout.rolledback"},
+	{Subcommand: "rollout", Verb: "paused", Color: "theme.rollout.paused"},
+	{Subcommand: "rollout", Verb: "resumed", Color: "theme.rollout.resumed"},
+	{Subcommand: "rollout", Verb: "restarted", Color: "theme.rollout.restarted"},
+	{Subcommand: "rollout", Verb: "successfully rolled out", Color: "theme.rollout.rolledout"},
+	{Subcommand: "scale", Verb: "scaled", Color: "theme.scale.scaled"},
+	{Subcommand: "set", Verb: "updated", Color: "theme.set.updated"},
+	{Subcommand: "set", Verb: "image updated", Color: "theme.set.updated"},
+	{Subcommand: "set", Verb: "env updated", Color: "theme.set.updated"},
+	{Subcommand: "set", Verb: "resource requirements updated", Color: "theme.set.updated"},
+	{Subcommand: "set", Verb: "selector updated", Color: "theme.set.updated"},
+	{Subcommand: "set", Verb: "serviceaccount updated", Color: "theme.set.updated"},
+	{Subcommand: "start-build", Verb: "started", Color: "theme.oc.started"},
+	{Subcommand: "taint", Verb: "tainted", Color: "theme.taint.tainted"},
+	{Subcommand: "taint", Verb: "untainted", Color: "theme.taint.untainted"},
+	{Subcommand: "taint", Verb: "modified", Color: "theme.taint.modified"},
+	{Subcommand: "uncordon", Verb: "uncordoned", Color: "theme.uncordon.uncordoned"},
+	{Subcommand: "wait", Verb: "condition met", Color: "theme.wait.conditionmet"},
+	{Subcommand: "wait", Verb: "timed out waiting for the condition", Color: "theme.wait.timedout", Prefix: true},
+}
+
+// hasVerbRules returns true if any of the rules is for the subcommand.
+// Rules without a subcommand don't count, as they only add to the
+// subcommands that already have verb coloring.
+func hasVerbRules(subcommand kubectl.Subcommand, rules ...[]config.VerbRule) bool {
+	for _, list := range rules {
+		for _, rule := range list {
+			if rule.Subcommand == string(subcommand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newVerbPrinter returns a [VerbPrinter] for the subcommand, using the
+// built-in [defaultVerbRules] followed by the user's rules, so that
+// the user's rules override the built-in ones on the same verb.
+func newVerbPrinter(subcommand kubectl.Subcommand, theme *config.Theme, userRules []config.VerbRule) *VerbPrinter {
+	p := &VerbPrinter{
+		DryRunColor:     theme.Apply.DryRun,
+		FallbackColor:   theme.Default,
+		VerbColor:       map[string]color.Color{},
+		PrefixVerbColor: map[string]color.Color{},
+	}
+	if c, ok := theme.LookupColor("theme." + string(subcommand) + ".dryrun"); ok {
+		p.DryRunColor = c
+	}
+	if c, ok := theme.LookupColor("theme." + string(subcommand) + ".fallback"); ok {
+		p.FallbackColor = c
+	}
+
+	for _, list := range [][]config.VerbRule{defaultVerbRules, userRules} {
+		for _, rule := range list {
+			if rule.Verb == "" || (rule.Subcommand != "" && rule.Subcommand != string(subcommand)) {
+				continue
+			}
+			c, ok := lookupVerbColor(rule.Color, theme)
+			if !ok {
+				continue
+			}
+			if rule.Prefix {
+				p.PrefixVerbColor[rule.Verb] = c
+			} else {
+				p.VerbColor[rule.Verb] = c
+			}
+		}
+	}
+	return p
+}
+
+// lookupVerbColor returns the color of a theme key, e.g "theme.taint.tainted",
+// or else parses it as a color, e.g "yellow".
+func lookupVerbColor(s string, theme *config.Theme) (color.Color, bool) {
+	if strings.HasPrefix(strings.ToLower(s), "theme.") {
+		c, ok := theme.LookupColor(s)
+		if !ok {
+			slog.Warn("Unknown theme key in verb rule.", "color", s)
+		}
+		return c, ok
+	}
+	c, err := color.Parse(s)
+	if err != nil {
+		slog.Warn("Invalid color in verb rule.", "color", s, "error", err)
+		return color.Color{}, false
+	}
+	return c, true
+}
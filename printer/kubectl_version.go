@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
 	"github.com/kubecolor/kubecolor/internal/bytesutil"
 	"gopkg.in/yaml.v3"
 )
@@ -26,23 +27,51 @@ type VersionPrinter struct {
 func (p *VersionPrinter) Print(r io.Reader, w io.Writer) {
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(nil, bytesutil.MaxLineLength)
-	any := false
+
+	// Buffer the lines, as the client version is printed before the server version,
+	// but both are needed to check the version skew.
+	var lines []string
+	var client, server kubeVersion
+	var hasClient, hasServer bool
 	for scanner.Scan() {
 		line := scanner.Text()
+		lines = append(lines, line)
+		switch key, val, _ := strings.Cut(line, ": "); key {
+		case "Client Version":
+			client, hasClient = parseKubeVersion(val)
+		case "Server Version":
+			server, hasServer = parseKubeVersion(val)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		slog.Error("Failed to print version output.", "error", err)
+	}
+
+	var skew versionSkew
+	var skewColor color.Color
+	var isSkewed bool
+	if hasClient && hasServer {
+		skew = newVersionSkew(client, server)
+		skewColor, isSkewed = skew.Color(p.Theme)
+	}
+
+	any := false
+	for _, line := range lines {
 		key, val, ok := strings.Cut(line, ": ")
 		if !ok {
 			fmt.Fprintln(w, line)
 			continue
 		}
+		valColor := ColorDataValue(val, p.Theme)
+		if isSkewed && (key == "Client Version" || key == "Server Version") {
+			valColor = skewColor
+		}
 		fmt.Fprintf(w, "%s: %s\n",
 			ColorDataKey(0, 2, p.Theme.Version.Key).Render(key),
-			ColorDataValue(val, p.Theme).Render(val),
+			valColor.Render(val),
 		)
 		any = true
 	}
-	if err := scanner.Err(); err != nil {
-		slog.Error("Failed to print version output.", "error", err)
-	}
 
 	// Check if any got printed, so we don't print version after an error like
 	// 	error: invalid argument "foo" for "--client" flag: strconv.ParseBool: parsing "foo": invalid syntax
@@ -54,6 +83,13 @@ func (p *VersionPrinter) Print(r io.Reader, w io.Writer) {
 			ColorDataValue(val, p.Theme).Render(val),
 		)
 	}
+
+	if isSkewed {
+		fmt.Fprintf(w, "%s: %s\n",
+			ColorDataKey(0, 2, p.Theme.Version.Key).Render("Version Skew"),
+			skewColor.Render(skew.Message()),
+		)
+	}
 }
 
 type VersionJSONInjectorPrinter struct {
@@ -72,6 +108,9 @@ func (vp *VersionJSONInjectorPrinter) Print(r io.Reader, w io.Writer) {
 		return
 	}
 	output["kubecolorVersion"] = vp.KubecolorVersion
+	if skew, ok := versionSkewFromOutput(output); ok {
+		output["versionSkew"] = skew.outputValue()
+	}
 	result, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
 		w.Write(b)
@@ -96,6 +135,9 @@ func (vp *VersionYAMLInjectorPrinter) Print(r io.Reader, w io.Writer) {
 		return
 	}
 	output["kubecolorVersion"] = vp.KubecolorVersion
+	if skew, ok := versionSkewFromOutput(output); ok {
+		output["versionSkew"] = skew.outputValue()
+	}
 	var buf bytes.Buffer
 	enc := yaml.NewEncoder(&buf)
 	enc.SetIndent(2)
@@ -0,0 +1,119 @@
+package printer
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+// Paths inside Kubernetes objects in "-o yaml" and "-o json" output,
+// in the format of [dataPath.String], e.g "status/conditions/[]/status".
+// The optional "items/[]/" prefix is for "kind: List" output.
+var (
+	dataPathConditionItem   = regexp.MustCompile(`^(items/\[\]/)?status/conditions/\[\]$`)
+	dataPathConditionStatus = regexp.MustCompile(`^(items/\[\]/)?status/conditions/\[\]/status$`)
+	dataPathPhase           = regexp.MustCompile(`^(items/\[\]/)?status/phase$`)
+	dataPathContainerReason = regexp.MustCompile(`^(items/\[\]/)?status/(init|ephemeral)?[cC]ontainerStatuses/\[\]/(state|lastState)/[^/]+/reason$`)
+	dataPathImage           = regexp.MustCompile(`(^|/)spec/(initContainers|containers|ephemeralContainers)/\[\]/image$`)
+)
+
+// negativeConditionTypes are condition types where "True" is bad,
+// as opposed to conditions like "Ready" where "False" is bad.
+var negativeConditionTypes = []string{
+	// Node conditions
+	"MemoryPressure",
+	"DiskPressure",
+	"PIDPressure",
+	"NetworkUnavailable",
+	// node-problem-detector
+	"KernelDeadlock",
+	"ReadonlyFilesystem",
+	"FrequentKubeletRestart",
+	"FrequentDockerRestart",
+	"FrequentContainerdRestart",
+	"CorruptDockerOverlay2",
+	// Deployments and Jobs
+	"ReplicaFailure",
+	"Failed",
+	// Common in CRDs, e.g Flux and Crossplane
+	"Stalled",
+	"Degraded",
+}
+
+// dataSegment is a single key in a [dataPath], or "[]" for list items.
+type dataSegment struct {
+	Key string
+	// Column is the YAML indentation of the key or list item dash.
+	// Not used in JSON.
+	Column int
+}
+
+// dataPath is the path to the current value in YAML or JSON output,
+// similar to [describe.Scanner.Path].
+type dataPath []dataSegment
+
+const dataPathListItem = "[]"
+
+// String returns the path joined by slashes, e.g "status/conditions/[]/status".
+func (p dataPath) String() string {
+	var sb strings.Builder
+	for i, seg := range p {
+		if i > 0 {
+			sb.WriteByte('/')
+		}
+		sb.WriteString(seg.Key)
+	}
+	return sb.String()
+}
+
+// colorKubernetesDataValue returns the color for a value based on where
+// in the Kubernetes object it is, e.g for "status.phase" or a container's image.
+// The value must be unquoted. The conditionType is the "type" of the
+// condition that the value belongs to, if any.
+func colorKubernetesDataValue(path, value, conditionType string, theme *config.Theme) (color.Color, bool) {
+	switch {
+	case dataPathConditionStatus.MatchString(path):
+		return conditionStatusColor(conditionType, value, theme)
+	case dataPathPhase.MatchString(path),
+		dataPathContainerReason.MatchString(path):
+		return statusColor(value, theme)
+	case dataPathImage.MatchString(path):
+		if isUnpinnedImage(value) {
+			return theme.Status.Warning, true
+		}
+		return theme.Data.Image, true
+	}
+	return color.Color{}, false
+}
+
+// conditionStatusColor colors "True", "False", and "Unknown"
+// based on if the condition type is considered good or bad.
+func conditionStatusColor(conditionType, status string, theme *config.Theme) (color.Color, bool) {
+	good, bad := "True", "False"
+	if slices.Contains(negativeConditionTypes, conditionType) {
+		good, bad = bad, good
+	}
+	switch status {
+	case good:
+		return theme.Status.Success, true
+	case bad:
+		return theme.Status.Error, true
+	case "Unknown":
+		return theme.Status.Warning, true
+	}
+	return color.Color{}, false
+}
+
+// isUnpinnedImage returns true for images using the "latest" tag,
+// or without any tag or digest, which also means "latest".
+func isUnpinnedImage(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image[strings.LastIndexByte(image, '/')+1:]
+	_, tag, ok := strings.Cut(name, ":")
+	return !ok || tag == "latest"
+}
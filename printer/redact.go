@@ -0,0 +1,121 @@
+package printer
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+)
+
+// redactedText is printed in place of masked values. It has the same length
+// regardless of the value, so it doesn't reveal the length of the secret.
+const redactedText = "••••••••"
+
+// Paths of Secret values in "-o yaml" and "-o json" output,
+// in the format of [dataPath.String].
+var (
+	dataPathKind           = regexp.MustCompile(`^(items/\[\]/)?kind$`)
+	dataPathSecretData     = regexp.MustCompile(`^(items/\[\]/)?(data|stringData)$`)
+	dataPathSecretDataItem = regexp.MustCompile(`^(items/\[\]/)?(data|stringData)/[^/]+$`)
+	dataPathEnvName        = regexp.MustCompile(`(^|/)env/\[\]/name$`)
+	dataPathEnvValue       = regexp.MustCompile(`(^|/)env/\[\]/value$`)
+)
+
+// bearerTokenRegex matches tokens in e.g "Authorization: Bearer eyJhbGciOi..."
+var bearerTokenRegex = regexp.MustCompile(`(?i)(\bbearer\s+)([^\s"',<>]+)`)
+
+// Redactor masks secret values, e.g when sharing your terminal.
+// A nil Redactor doesn't mask anything.
+type Redactor struct {
+	Theme *config.Theme
+	// Keys is case-insensitive glob patterns of keys
+	// whose values are masked, e.g "*password*".
+	Keys []string
+}
+
+// NewRedactor returns a [Redactor] if redaction is enabled, or else nil.
+func NewRedactor(cfg config.Redact, theme *config.Theme) *Redactor {
+	if !cfg.Enabled {
+		return nil
+	}
+	keys := make([]string, len(cfg.Keys))
+	for i, k := range cfg.Keys {
+		keys[i] = strings.ToLower(k)
+	}
+	return &Redactor{Theme: theme, Keys: keys}
+}
+
+// MatchKey returns true if the values of the key should be masked.
+func (r *Redactor) MatchKey(key string) bool {
+	if r == nil || key == "" {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, pattern := range r.Keys {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDataValue returns true if the value at the path in YAML or JSON
+// output should be masked. The key is the last key in the path, and envName
+// is the "name" of the environment variable the value belongs to, if any.
+func (r *Redactor) matchDataValue(path, key, objectKind, envName string) bool {
+	if r == nil {
+		return false
+	}
+	if objectKind == "Secret" && (dataPathSecretDataItem.MatchString(path) || dataPathLastApplied.MatchString(path)) {
+		return true
+	}
+	if dataPathEnvValue.MatchString(path) && r.MatchKey(envName) {
+		return true
+	}
+	return r.MatchKey(key)
+}
+
+// Render returns the colored placeholder for a masked value.
+func (r *Redactor) Render() string {
+	return r.Theme.Data.Redacted.Render(redactedText)
+}
+
+// RedactBearerTokens masks any bearer tokens in the text, while the rest
+// of the text is colored using the render function.
+// Returns false if there were no tokens.
+func (r *Redactor) RedactBearerTokens(s string, render func(string) string) (string, bool) {
+	if r == nil {
+		return s, false
+	}
+	matches := bearerTokenRegex.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s, false
+	}
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		// m[4]:m[5] is the token submatch
+		if m[4] > last {
+			sb.WriteString(render(s[last:m[4]]))
+		}
+		sb.WriteString(r.Render())
+		last = m[5]
+	}
+	if last < len(s) {
+		sb.WriteString(render(s[last:]))
+	}
+	return sb.String(), true
+}
+
+// isRedactable returns false for values that are never secret,
+// e.g "<set to the key 'password' in secret 'db'>", "<none>", or booleans.
+func isRedactable(value string) bool {
+	switch value {
+	case "", "true", "false", "null",
+		// placeholders of "kubectl config view" without "--raw"
+		"DATA+OMITTED", "REDACTED":
+		return false
+	}
+	return !strings.HasPrefix(value, "<")
+}
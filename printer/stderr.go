@@ -12,7 +12,8 @@ import (
 
 // StderrPrinter is a used on stderr input.
 type StderrPrinter struct {
-	Theme *config.Theme
+	Theme    *config.Theme
+	Redactor *Redactor
 }
 
 // ensures it implements the interface
@@ -24,7 +25,8 @@ func (p *StderrPrinter) Print(r io.Reader, w io.Writer) {
 	scanner.Buffer(nil, bytesutil.MaxLineLength)
 
 	logsPrinter := LogsPrinter{
-		Theme: p.Theme,
+		Theme:    p.Theme,
+		Redactor: p.Redactor,
 	}
 	logsPrinterReader := strings.NewReader("")
 
@@ -43,6 +45,9 @@ func (p *StderrPrinter) Print(r io.Reader, w io.Writer) {
 
 func (p *StderrPrinter) formatLine(line string) (string, bool) {
 	if strings.HasPrefix(strings.ToLower(line), "error") {
+		if s, ok := p.Redactor.RedactBearerTokens(line, p.Theme.Stderr.Error.Render); ok {
+			return s, true
+		}
 		return p.Theme.Stderr.Error.Render(line), true
 	}
 
@@ -0,0 +1,148 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/internal/stringutil"
+	"github.com/kubecolor/kubecolor/scanner/tablescan"
+)
+
+// summarySeparator is printed between the counts in the summary footer.
+const summarySeparator = " · "
+
+// Summary counts the rows in table output, e.g per STATUS in "kubectl get pods",
+// and the verbs in e.g "kubectl apply" output, so they can be printed as a footer
+// by --kubecolor-summary. A nil Summary doesn't count anything.
+type Summary struct {
+	Theme *config.Theme
+
+	statuses summaryCounts
+	verbs    summaryCounts
+
+	hasReady   bool
+	ready      int
+	readyTotal int
+
+	hasRestarts bool
+	restarts    int
+}
+
+// summaryCounts holds counts in the order they were first seen.
+type summaryCounts struct {
+	keys   []string
+	counts map[string]int
+	colors map[string]color.Color
+}
+
+func (c *summaryCounts) add(key string, keyColor color.Color) {
+	if c.counts == nil {
+		c.counts = map[string]int{}
+		c.colors = map[string]color.Color{}
+	}
+	if _, ok := c.counts[key]; !ok {
+		c.keys = append(c.keys, key)
+		c.colors[key] = keyColor
+	}
+	c.counts[key]++
+}
+
+// sorted returns the keys with the highest counts first.
+func (c *summaryCounts) sorted() []string {
+	keys := slices.Clone(c.keys)
+	slices.SortStableFunc(keys, func(a, b string) int {
+		return c.counts[b] - c.counts[a]
+	})
+	return keys
+}
+
+// addRow counts the STATUS, READY, and RESTARTS cells of a table row,
+// where the columns are the names from the table header.
+func (s *Summary) addRow(columns []string, cells []tablescan.Cell) {
+	if s == nil {
+		return
+	}
+	for i, cell := range cells {
+		if i >= len(columns) || cell.Trimmed == "" {
+			continue
+		}
+		switch strings.ToUpper(columns[i]) {
+		case "STATUS":
+			c, _ := statusColor(cell.Trimmed, s.Theme)
+			s.statuses.add(cell.Trimmed, c)
+		case "READY":
+			left, right, ok := stringutil.ParseRatio(cell.Trimmed)
+			if !ok {
+				continue
+			}
+			ready, _ := strconv.Atoi(left)
+			total, _ := strconv.Atoi(right)
+			s.hasReady = true
+			s.ready += ready
+			s.readyTotal += total
+		case "RESTARTS":
+			// e.g "3 (5m ago)"
+			count, _, _ := strings.Cut(cell.Trimmed, " ")
+			if n, err := strconv.Atoi(count); err == nil {
+				s.hasRestarts = true
+				s.restarts += n
+			}
+		}
+	}
+}
+
+// addVerb counts a verb from e.g "deployment.apps/foo configured".
+func (s *Summary) addVerb(verb string, verbColor color.Color) {
+	if s == nil {
+		return
+	}
+	s.verbs.add(verb, verbColor)
+}
+
+// Print writes the summary footer, or nothing if nothing was counted, e.g:
+//
+//	180 Running · 3 CrashLoopBackOff · 2 Pending
+//	ready 181/185 · 42 restarts
+func (s *Summary) Print(w io.Writer) {
+	if s == nil {
+		return
+	}
+	for _, line := range [][]string{
+		s.countParts(&s.statuses),
+		s.tableParts(),
+		s.countParts(&s.verbs),
+	} {
+		if len(line) > 0 {
+			fmt.Fprintln(w, strings.Join(line, s.Theme.Table.Summary.Render(summarySeparator)))
+		}
+	}
+}
+
+func (s *Summary) countParts(c *summaryCounts) []string {
+	var parts []string
+	for _, key := range c.sorted() {
+		parts = append(parts, s.Theme.Table.Summary.Render(strconv.Itoa(c.counts[key])+" ")+c.colors[key].Render(key))
+	}
+	return parts
+}
+
+func (s *Summary) tableParts() []string {
+	var parts []string
+	if s.hasReady {
+		ratio := fmt.Sprintf("%d/%d", s.ready, s.readyTotal)
+		ratioColor := s.Theme.Data.Ratio.Unequal
+		if s.ready == s.readyTotal {
+			ratioColor = s.Theme.Data.Ratio.Equal
+		}
+		parts = append(parts, s.Theme.Table.Summary.Render("ready ")+ratioColor.Render(ratio))
+	}
+	if s.hasRestarts {
+		parts = append(parts, s.Theme.Data.Number.Render(strconv.Itoa(s.restarts))+s.Theme.Table.Summary.Render(" restarts"))
+	}
+	return parts
+}
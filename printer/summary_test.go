@@ -0,0 +1,63 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+	"github.com/kubecolor/kubecolor/config/testconfig"
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestSummary_table(t *testing.T) {
+	summary := &Summary{Theme: testconfig.NullTheme}
+	printer := TablePrinter{WithHeader: true, Theme: testconfig.NullTheme, Summary: summary}
+
+	input := testutil.NewHereDoc(`
+		NAMESPACE     NAME          READY   STATUS             RESTARTS       AGE
+		default       nginx-1       1/1     Running            0              6d
+		default       nginx-2       0/1     CrashLoopBackOff   12 (5m ago)    6d
+		kube-system   coredns       1/1     Running            2 (1h ago)     6d
+		kube-system   etcd          2/2     Running            0              6d
+		default       job-x2kqp     0/1     Completed          0              1h
+	`)
+	printer.Print(strings.NewReader(input), &bytes.Buffer{})
+
+	var outBuf bytes.Buffer
+	summary.Print(&outBuf)
+	testutil.Equal(t, "3 Running · 1 CrashLoopBackOff · 1 Completed\nready 4/6 · 14 restarts\n", outBuf.String())
+}
+
+func TestSummary_verbs(t *testing.T) {
+	summary := &Summary{Theme: testconfig.NullTheme}
+	printer := VerbPrinter{
+		VerbColor: map[string]color.Color{
+			"created":    {},
+			"configured": {},
+			"unchanged":  {},
+		},
+		Summary: summary,
+	}
+
+	input := testutil.NewHereDoc(`
+		deployment.apps/foo created
+		deployment.apps/bar configured
+		service/foo unchanged
+		service/bar unchanged
+		configmap/baz unchanged (dry run)
+	`)
+	printer.Print(strings.NewReader(input), &bytes.Buffer{})
+
+	var outBuf bytes.Buffer
+	summary.Print(&outBuf)
+	testutil.Equal(t, "3 unchanged · 1 created · 1 configured\n", outBuf.String())
+}
+
+func TestSummary_empty(t *testing.T) {
+	var outBuf bytes.Buffer
+	(&Summary{Theme: &config.Theme{}}).Print(&outBuf)
+	(*Summary)(nil).Print(&outBuf)
+	testutil.Equal(t, "", outBuf.String())
+}
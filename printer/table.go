@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"path"
+	"slices"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
@@ -18,8 +20,16 @@ type TablePrinter struct {
 	DarkBackground bool
 	Theme          *config.Theme
 	ColumnFilter   func(columnIndex int, column string) string
+	RowRules       []config.RowRule
+	Summary        *Summary
+	Identity       *IdentityColors
+	// OnlyFirstLineHeader disables guessing which lines are headers, for tables
+	// where rows can look like headers, e.g "*.*" in "kubectl auth can-i --list".
+	OnlyFirstLineHeader bool
 
 	hasLeadingNamespaceColumn bool
+	// columns holds the header names of the current table, used by the RowRules.
+	columns []string
 }
 
 // ensures it implements the interface
@@ -43,20 +53,9 @@ func (p *TablePrinter) Print(r io.Reader, w io.Writer) {
 			fmt.Fprint(w, "\n")
 			continue
 		}
-		peekNextLine, hasNextLine := scanner.PeekText()
-		if (p.WithHeader && isFirstLine) ||
-			isAllUpper(scanner.Text()) ||
-			(hasNextLine && isOnlySymbols(peekNextLine)) ||
-			isOnlySymbols(scanner.Text()) {
-
+		if p.isHeader(scanner, isFirstLine) {
 			isFirstLine = false
-			leadingSpaces := scanner.LeadingSpaces()
-			withoutSpaces := scanner.Text()[len(leadingSpaces):]
-			fmt.Fprintf(w, "%s%s\n", leadingSpaces, p.Theme.Table.Header.Render(withoutSpaces))
-
-			if strings.EqualFold(cells[0].Trimmed, "namespace") {
-				p.hasLeadingNamespaceColumn = true
-			}
+			p.printHeader(w, scanner)
 			continue
 		}
 
@@ -68,6 +67,37 @@ func (p *TablePrinter) Print(r io.Reader, w io.Writer) {
 	}
 }
 
+// isHeader returns true if the current line of the scanner is a table header.
+func (p *TablePrinter) isHeader(scanner *tablescan.Scanner, isFirstLine bool) bool {
+	if p.OnlyFirstLineHeader {
+		return p.WithHeader && isFirstLine
+	}
+	peekNextLine, hasNextLine := scanner.PeekText()
+	return (p.WithHeader && isFirstLine) ||
+		isAllUpper(scanner.Text()) ||
+		(hasNextLine && isOnlySymbols(peekNextLine)) ||
+		isOnlySymbols(scanner.Text())
+}
+
+// printHeader prints the current line of the scanner as a table header.
+func (p *TablePrinter) printHeader(w io.Writer, scanner *tablescan.Scanner) {
+	leadingSpaces := scanner.LeadingSpaces()
+	withoutSpaces := scanner.Text()[len(leadingSpaces):]
+	fmt.Fprintf(w, "%s%s\n", leadingSpaces, p.Theme.Table.Header.Render(withoutSpaces))
+
+	if isOnlySymbols(scanner.Text()) {
+		// keep the column names when the header is followed by e.g "----  ------"
+		return
+	}
+	p.columns = p.columns[:0]
+	for _, cell := range scanner.Cells() {
+		p.columns = append(p.columns, cell.Trimmed)
+	}
+	if strings.EqualFold(scanner.Cells()[0].Trimmed, "namespace") {
+		p.hasLeadingNamespaceColumn = true
+	}
+}
+
 // printTableFormat prints a line to w in kubectl "table" Format.
 // Table format is something like:
 //
@@ -79,21 +109,97 @@ func (p *TablePrinter) Print(r io.Reader, w io.Writer) {
 //	nginx-dplns              1/1     Running   0          31h
 //	nginx-lpv5x              1/1     Running   0          31h
 func (p *TablePrinter) printLineAsTableFormat(w io.Writer, cells []tablescan.Cell, colorsPreset []color.Color) {
+	p.Summary.addRow(p.columns, cells)
+
+	var line strings.Builder
 	for i, cell := range cells {
 		c := p.getColumnBaseColor(i, colorsPreset)
 
+		if colored, ok := p.identityCell(i, cell.Trimmed, c); ok {
+			line.WriteString(colored)
+			line.WriteString(cell.TrailingSpaces)
+			continue
+		}
+
 		cellText := cell.Trimmed
 		if p.ColumnFilter != nil {
 			cellText = p.ColumnFilter(i, cellText)
 		}
 		// Write colored column
 		if cellText != "" {
-			fmt.Fprint(w, c.Render(cellText))
+			line.WriteString(c.Render(cellText))
 		}
-		fmt.Fprint(w, cell.TrailingSpaces)
+		line.WriteString(cell.TrailingSpaces)
 	}
 
-	fmt.Fprintf(w, "\n")
+	fmt.Fprintf(w, "%s\n", p.renderRow(cells, line.String()))
+}
+
+// identityCell colors the NAMESPACE and NODE cells by their value,
+// and the kind prefix in NAME cells, e.g "pod/" in "pod/nginx",
+// when [TablePrinter.Identity] is enabled.
+func (p *TablePrinter) identityCell(index int, text string, baseColor color.Color) (string, bool) {
+	if p.Identity == nil || index >= len(p.columns) {
+		return "", false
+	}
+	switch strings.ToUpper(p.columns[index]) {
+	case "NAMESPACE", "NODE":
+		if c, ok := p.Identity.Color(text); ok {
+			return c.Render(text), true
+		}
+	case "NAME":
+		if kind, name, ok := strings.Cut(text, "/"); ok {
+			if colored, ok := p.Identity.RenderKindPrefix(kind + "/"); ok {
+				return colored + baseColor.Render(name), true
+			}
+		}
+	}
+	return "", false
+}
+
+// renderRow applies the style of the first of the [TablePrinter.RowRules]
+// that matches the cells, beneath the already colored line.
+func (p *TablePrinter) renderRow(cells []tablescan.Cell, line string) string {
+	for _, rule := range p.RowRules {
+		if p.matchRowRule(rule, cells) {
+			return rule.Style.RenderBeneath(line)
+		}
+	}
+	return line
+}
+
+func (p *TablePrinter) matchRowRule(rule config.RowRule, cells []tablescan.Cell) bool {
+	if rule.Status == "" && rule.Value == "" {
+		return false
+	}
+	if rule.Status != "" && !slices.ContainsFunc(cells, func(cell tablescan.Cell) bool {
+		return cellHasStatusLevel(cell.Trimmed, rule.Status)
+	}) {
+		return false
+	}
+	if rule.Value == "" {
+		return true
+	}
+	for i, cell := range cells {
+		if rule.Column != "" && (i >= len(p.columns) || !strings.EqualFold(p.columns[i], rule.Column)) {
+			continue
+		}
+		if ok, _ := path.Match(rule.Value, cell.Trimmed); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// cellHasStatusLevel returns true if the cell is a status of the given level,
+// including any of the statuses in e.g "Init:Error,Terminating".
+func cellHasStatusLevel(cell string, level config.StatusLevel) bool {
+	for status := range strings.SplitSeq(cell, ",") {
+		if statusLevel(status) == level {
+			return true
+		}
+	}
+	return false
 }
 
 func (p *TablePrinter) getColumnBaseColor(index int, colorsPreset []color.Color) color.Color {
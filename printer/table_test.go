@@ -3,8 +3,11 @@ package printer
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
 	"github.com/kubecolor/kubecolor/config/testconfig"
 	"github.com/kubecolor/kubecolor/testutil"
 )
@@ -20,3 +23,40 @@ func TestTablePrinter_fail(t *testing.T) {
 	testutil.Equal(t, "", outBuf.String(), "output")
 	testutil.Equal(t, "level=ERROR msg=\"Failed to print table output.\" error=test\n", logBuf.String(), "logs")
 }
+
+func TestTablePrinter_rowRules(t *testing.T) {
+	printer := TablePrinter{
+		WithHeader: true,
+		Theme:      testconfig.NullTheme,
+		ColumnFilter: func(_ int, column string) string {
+			if colored, ok := ColorStatus(column, testconfig.NullTheme); ok {
+				return colored
+			}
+			return column
+		},
+		RowRules: []config.RowRule{
+			{Status: config.StatusLevelError, Style: color.MustParse("bg=red")},
+			{Column: "status", Value: "Completed", Style: color.MustParse("dim")},
+			{Value: "*-debug", Style: color.MustParse("bold")},
+		},
+	}
+
+	input := testutil.NewHereDoc(`
+		NAME          READY   STATUS             RESTARTS   AGE
+		nginx         1/1     Running            0          6d
+		nginx-crash   0/1     CrashLoopBackOff   12         6d
+		job-x2kqp     0/1     Completed          0          1h
+		Completed     1/1     Running            0          1h
+		app-debug     1/1     Running            0          1h
+	`)
+	want := "NAME          READY   STATUS             RESTARTS   AGE\n" +
+		"nginx         1/1     Running            0          6d\n" +
+		"\033[41mnginx-crash   0/1     CrashLoopBackOff   12         6d\033[0m\n" +
+		"\033[2mjob-x2kqp     0/1     Completed          0          1h\033[0m\n" +
+		"Completed     1/1     Running            0          1h\n" +
+		"\033[1mapp-debug     1/1     Running            0          1h\033[0m\n"
+
+	var outBuf bytes.Buffer
+	printer.Print(strings.NewReader(input), &outBuf)
+	testutil.Equal(t, want, outBuf.String())
+}
@@ -0,0 +1,49 @@
+package printer
+
+import "strings"
+
+// treeGuides returns the tree guides of each line, e.g "│  └─ ", from the depth
+// of each line, starting at 0. Lines with a negative depth aren't part of
+// the tree, and get an empty string, but don't end the tree.
+func treeGuides(depths []int) []string {
+	// isLast is true for lines that don't have any more siblings below them
+	isLast := make([]bool, len(depths))
+	var seenAtDepth []bool
+	for i := len(depths) - 1; i >= 0; i-- {
+		depth := depths[i]
+		if depth < 0 {
+			continue
+		}
+		for len(seenAtDepth) <= depth {
+			seenAtDepth = append(seenAtDepth, false)
+		}
+		isLast[i] = !seenAtDepth[depth]
+		seenAtDepth[depth] = true
+		clear(seenAtDepth[depth+1:])
+	}
+
+	guides := make([]string, len(depths))
+	// lastAtDepth is true if the parent at that depth was the last of its siblings
+	var lastAtDepth []bool
+	for i, depth := range depths {
+		if depth < 0 {
+			continue
+		}
+		lastAtDepth = append(lastAtDepth[:min(depth, len(lastAtDepth))], isLast[i])
+		var sb strings.Builder
+		for _, last := range lastAtDepth[:len(lastAtDepth)-1] {
+			if last {
+				sb.WriteString("   ")
+			} else {
+				sb.WriteString("│  ")
+			}
+		}
+		if isLast[i] {
+			sb.WriteString("└─ ")
+		} else {
+			sb.WriteString("├─ ")
+		}
+		guides[i] = sb.String()
+	}
+	return guides
+}
@@ -110,6 +110,19 @@ func ColorDuration(age time.Duration, thresholds config.DurationSlice, theme *co
 	return theme.Data.Duration
 }
 
+// ColorRatio returns the color to use for a ratio of e.g ready containers,
+// such as "1/3" in "kubectl get pods" or "1 of 3" in "kubectl rollout status".
+func ColorRatio(left, right string, theme *config.Theme) color.Color {
+	switch {
+	case left == "0" && right == "0":
+		return theme.Data.Ratio.Zero
+	case left == right:
+		return theme.Data.Ratio.Equal
+	default:
+		return theme.Data.Ratio.Unequal
+	}
+}
+
 // ColorStatus returns the color that should be used for a given status text.
 func ColorStatus(status string, theme *config.Theme) (string, bool) {
 	if strings.ContainsRune(status, ',') {
@@ -131,6 +144,38 @@ func ColorStatus(status string, theme *config.Theme) (string, bool) {
 }
 
 func colorSingleStatus(status string, theme *config.Theme) (string, bool) {
+	if c, ok := statusColor(status, theme); ok {
+		return c.Render(status), true
+	}
+	return status, false
+}
+
+// statusColor returns the color for a single status text, like "Running".
+func statusColor(status string, theme *config.Theme) (color.Color, bool) {
+	switch statusLevel(status) {
+	case config.StatusLevelError:
+		return theme.Status.Error, true
+	case config.StatusLevelWarning:
+		return theme.Status.Warning, true
+	case config.StatusLevelSuccess:
+		return theme.Status.Success, true
+	}
+
+	// Also allow some data-related values, common in CRD statuses (e.g. READY column with True/False)
+	switch strings.TrimPrefix(status, "Init:") {
+	case "null", "<none>", "<unknown>", "<unset>", "<nil>", "<invalid>":
+		return theme.Data.Null, true
+	case "true", "True", "TRUE":
+		return theme.Data.True, true
+	case "false", "False", "FALSE":
+		return theme.Data.False, true
+	}
+	return color.Color{}, false
+}
+
+// statusLevel returns the kind of a single status text, e.g "error"
+// for "CrashLoopBackOff", or an empty string if it's not a known status.
+func statusLevel(status string) config.StatusLevel {
 	switch strings.TrimPrefix(status, "Init:") {
 	case
 		// from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/events/event.go
@@ -199,7 +244,7 @@ func colorSingleStatus(status string, theme *config.Theme) (string, bool) {
 		"StartError",
 		// PVC status
 		"Lost":
-		return theme.Status.Error.Render(status), true
+		return config.StatusLevelError
 	case
 		// from https://github.com/kubernetes/kubernetes/blob/master/pkg/kubelet/events/event.go
 		// Container event reason list
@@ -240,7 +285,7 @@ func colorSingleStatus(status string, theme *config.Theme) (string, bool) {
 		"Released",
 
 		"ScalingReplicaSet":
-		return theme.Status.Warning.Render(status), true
+		return config.StatusLevelWarning
 	case
 		"Running",
 		"Completed",
@@ -261,17 +306,9 @@ func colorSingleStatus(status string, theme *config.Theme) (string, bool) {
 
 		// PVC status
 		"Bound":
-		return theme.Status.Success.Render(status), true
-
-	// Also allow some data-related values, common in CRD statuses (e.g. READY column with True/False)
-	case "null", "<none>", "<unknown>", "<unset>", "<nil>", "<invalid>":
-		return theme.Data.Null.Render(status), true
-	case "true", "True", "TRUE":
-		return theme.Data.True.Render(status), true
-	case "false", "False", "FALSE":
-		return theme.Data.False.Render(status), true
+		return config.StatusLevelSuccess
 	}
-	return status, false
+	return ""
 }
 
 // findIndent returns a length of indent (spaces at left) in the given line
@@ -0,0 +1,151 @@
+package printer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
+)
+
+// supportedMinorSkew is how many minor versions kubectl may be older or newer
+// than the kube-apiserver, according to the Kubernetes version skew policy:
+// https://kubernetes.io/releases/version-skew-policy/#kubectl
+const supportedMinorSkew = 1
+
+// kubeVersionRegex matches e.g "v1.27.5", "v1.27.5-gke.200", "v1.28.3+k3s1", and "1.29"
+var kubeVersionRegex = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(?:[-+].*)?$`)
+
+// gitVersionRegex matches the version in the old "kubectl version" output, e.g:
+//
+//	Client Version: version.Info{Major:"1", Minor:"19", GitVersion:"v1.19.3", ...}
+var gitVersionRegex = regexp.MustCompile(`GitVersion:"([^"]+)"`)
+
+type kubeVersion struct {
+	Major int
+	Minor int
+}
+
+func (v kubeVersion) String() string {
+	return fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+}
+
+// parseKubeVersion parses the major and minor version from a "kubectl version" value,
+// ignoring any vendor suffixes like "-gke.200" or "+k3s1".
+func parseKubeVersion(s string) (kubeVersion, bool) {
+	if m := gitVersionRegex.FindStringSubmatch(s); m != nil {
+		s = m[1]
+	}
+	m := kubeVersionRegex.FindStringSubmatch(s)
+	if m == nil {
+		return kubeVersion{}, false
+	}
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return kubeVersion{}, false
+	}
+	minor, err := strconv.Atoi(m[2])
+	if err != nil {
+		return kubeVersion{}, false
+	}
+	return kubeVersion{Major: major, Minor: minor}, true
+}
+
+// versionSkew is the difference between the client (kubectl) and server (kube-apiserver) versions.
+type versionSkew struct {
+	Client kubeVersion
+	Server kubeVersion
+	// Minor is how many minor versions the client is newer than the server,
+	// or negative if the client is older.
+	Minor int
+}
+
+func newVersionSkew(client, server kubeVersion) versionSkew {
+	return versionSkew{Client: client, Server: server, Minor: client.Minor - server.Minor}
+}
+
+func (s versionSkew) minorAbs() int {
+	if s.Minor < 0 {
+		return -s.Minor
+	}
+	return s.Minor
+}
+
+// Supported returns true if the client is within the supported skew of the server.
+func (s versionSkew) Supported() bool {
+	return s.Client.Major == s.Server.Major && s.minorAbs() <= supportedMinorSkew
+}
+
+// Message returns a one-line explanation of the skew, e.g
+// "client v1.31 is 2 minor versions newer than server v1.29, but only ±1 is supported"
+func (s versionSkew) Message() string {
+	if s.Client.Major != s.Server.Major {
+		return fmt.Sprintf("client %s and server %s have different major versions", s.Client, s.Server)
+	}
+	if s.Minor == 0 {
+		return fmt.Sprintf("client and server are both %s", s.Client)
+	}
+	direction := "newer"
+	if s.Minor < 0 {
+		direction = "older"
+	}
+	plural := "s"
+	if s.minorAbs() == 1 {
+		plural = ""
+	}
+	msg := fmt.Sprintf("client %s is %d minor version%s %s than server %s", s.Client, s.minorAbs(), plural, direction, s.Server)
+	if !s.Supported() {
+		msg += fmt.Sprintf(", but only ±%d is supported", supportedMinorSkew)
+	}
+	return msg
+}
+
+// Color returns the color to highlight the versions with, where skews
+// just outside the supported policy are warnings, and the rest are dangers.
+func (s versionSkew) Color(theme *config.Theme) (color.Color, bool) {
+	switch {
+	case s.Supported():
+		return color.Color{}, false
+	case s.Client.Major == s.Server.Major && s.minorAbs() == supportedMinorSkew+1:
+		return theme.Version.SkewWarning, true
+	default:
+		return theme.Version.SkewDanger, true
+	}
+}
+
+// versionSkewFromOutput returns the skew from the parsed "kubectl version -o json/yaml" output.
+// Returns false if the output is missing the client or server version.
+func versionSkewFromOutput(output map[string]any) (versionSkew, bool) {
+	client, ok := gitVersionFromOutput(output, "clientVersion")
+	if !ok {
+		return versionSkew{}, false
+	}
+	server, ok := gitVersionFromOutput(output, "serverVersion")
+	if !ok {
+		return versionSkew{}, false
+	}
+	return newVersionSkew(client, server), true
+}
+
+func gitVersionFromOutput(output map[string]any, key string) (kubeVersion, bool) {
+	info, ok := output[key].(map[string]any)
+	if !ok {
+		return kubeVersion{}, false
+	}
+	gitVersion, ok := info["gitVersion"].(string)
+	if !ok {
+		return kubeVersion{}, false
+	}
+	return parseKubeVersion(gitVersion)
+}
+
+// outputValue returns the skew as a value for the "versionSkew" field
+// injected into the "kubectl version -o json/yaml" output.
+func (s versionSkew) outputValue() map[string]any {
+	return map[string]any{
+		"minorVersions": s.Minor,
+		"supported":     s.Supported(),
+		"message":       s.Message(),
+	}
+}
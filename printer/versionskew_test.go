@@ -0,0 +1,77 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/kubecolor/kubecolor/testutil"
+)
+
+func TestParseKubeVersion(t *testing.T) {
+	tests := []struct {
+		input  string
+		want   kubeVersion
+		wantOK bool
+	}{
+		{input: "v1.29.0", want: kubeVersion{1, 29}, wantOK: true},
+		{input: "v1.27.5-gke.200", want: kubeVersion{1, 27}, wantOK: true},
+		{input: "v1.28.3+k3s1", want: kubeVersion{1, 28}, wantOK: true},
+		{input: "v1.30.4-eks-a737599", want: kubeVersion{1, 30}, wantOK: true},
+		{input: "1.29", want: kubeVersion{1, 29}, wantOK: true},
+		{input: `version.Info{Major:"1", Minor:"19", GitVersion:"v1.19.3", GitCommit:"1e11e4a"}`, want: kubeVersion{1, 19}, wantOK: true},
+		{input: "v5", wantOK: false},
+		{input: "unknown", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, ok := parseKubeVersion(tt.input)
+			testutil.Equal(t, tt.wantOK, ok)
+			testutil.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVersionSkew_Message(t *testing.T) {
+	tests := []struct {
+		name          string
+		client        kubeVersion
+		server        kubeVersion
+		wantSupported bool
+		wantMessage   string
+	}{
+		{
+			name:          "same",
+			client:        kubeVersion{1, 29},
+			server:        kubeVersion{1, 29},
+			wantSupported: true,
+			wantMessage:   "client and server are both v1.29",
+		},
+		{
+			name:          "client one older",
+			client:        kubeVersion{1, 28},
+			server:        kubeVersion{1, 29},
+			wantSupported: true,
+			wantMessage:   "client v1.28 is 1 minor version older than server v1.29",
+		},
+		{
+			name:          "client two newer",
+			client:        kubeVersion{1, 31},
+			server:        kubeVersion{1, 29},
+			wantSupported: false,
+			wantMessage:   "client v1.31 is 2 minor versions newer than server v1.29, but only ±1 is supported",
+		},
+		{
+			name:          "different major",
+			client:        kubeVersion{2, 0},
+			server:        kubeVersion{1, 29},
+			wantSupported: false,
+			wantMessage:   "client v2.0 and server v1.29 have different major versions",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew := newVersionSkew(tt.client, tt.server)
+			testutil.Equal(t, tt.wantSupported, skew.Supported())
+			testutil.Equal(t, tt.wantMessage, skew.Message())
+		})
+	}
+}
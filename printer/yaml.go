@@ -2,23 +2,115 @@ package printer
 
 import (
 	"bufio"
-	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/kubecolor/kubecolor/config"
+	"github.com/kubecolor/kubecolor/config/color"
 	"github.com/kubecolor/kubecolor/internal/bytesutil"
-	"github.com/kubecolor/kubecolor/internal/stringutil"
 )
 
-// YAMLPrinter is used on "kubectl get -o yaml" output
+// YAMLPrinter is used on "kubectl get -o yaml" output.
+//
+// It tokenizes one line at a time, while keeping track of YAML constructs
+// that span multiple lines, such as block scalars (`key: |`), multiline
+// quoted and plain strings, and flow collections (`{a: 1}` and `[1, 2]`).
+// This keeps the output streaming, e.g for "kubectl get -w -o yaml".
 type YAMLPrinter struct {
-	Theme                 *config.Theme
-	inString              bool
-	multilineStringIndent int
-	nextLineSetsIndent    bool
+	Theme    *config.Theme
+	Fold     config.Fold
+	Redactor *Redactor
+	// DecodeSecrets shows the decoded value beside each base64 encoded
+	// value in a Secret's data.
+	DecodeSecrets bool
+
+	// blockScalarIndent is the indentation of the block scalar
+	// (multiline string) that is currently being printed, or 0 if none.
+	blockScalarIndent int
+	// blockScalarPending is true when the previous line started a block scalar
+	// without an indentation indicator, so the next line sets the indentation.
+	blockScalarPending bool
+	// blockScalarParent is the indentation of the node owning the block scalar.
+	blockScalarParent int
+
+	// quote is the quote character of an unclosed multiline string, or 0 if none.
+	quote byte
+
+	// inPlain is true when the previous line had a plain (unquoted) string,
+	// which can continue on the following lines if they are indented more
+	// than plainIndent.
+	inPlain     bool
+	plainIndent int
+
+	// flow is the stack of unclosed flow collections: '{' or '['.
+	flow          []byte
+	flowExpectKey bool
+	flowBaseDepth int
+
+	// path is the keys leading up to the current line, used to color
+	// some values differently based on where in a Kubernetes object they are.
+	path dataPath
+
+	// condition holds the lines of a "status.conditions[]" list item, which
+	// is buffered until it's complete, because its "status" depends on its "type",
+	// but kubectl sorts "status" before "type".
+	condition *yamlCondition
+	// conditionType is the "type" of the condition currently being printed.
+	conditionType string
+
+	// fold is the value currently being hidden by [YAMLPrinter.Fold],
+	// or by the [YAMLPrinter.Redactor].
+	fold *yamlFold
+
+	// objectKind is the "kind" of the current Kubernetes object, if known.
+	objectKind string
+	// secretData holds the lines of "data" or "stringData" while waiting for
+	// the "kind" of the object, as kubectl sorts "data" before "kind",
+	// but only Secret data is masked by the [YAMLPrinter.Redactor]
+	// or decoded by [YAMLPrinter.DecodeSecrets].
+	secretData *yamlSecretData
+	// envName is the "name" of the current environment variable in a container spec.
+	envName string
+}
+
+type yamlSecretData struct {
+	column int
+	lines  []string
+}
+
+type yamlCondition struct {
+	dashColumn int
+	lines      []string
+}
+
+type yamlFold struct {
+	// header is the already colored "key:" line that owns the hidden value.
+	header string
+	column int
+	// listItems is true if "- " list items at the same column are included,
+	// as kubectl doesn't indent lists.
+	listItems bool
+	// hideOnly is true when the header already has a placeholder,
+	// e.g from the [YAMLPrinter.Redactor].
+	hideOnly bool
+	// pendingPEM is true when the header is a block scalar that
+	// is only hidden if it turns out to be a PEM certificate or key,
+	// and then keyHeader is printed instead of the header.
+	pendingPEM bool
+	keyHeader  string
+	lines      int
+}
+
+func (f *yamlFold) contains(line string) bool {
+	column := findIndent(line)
+	if strings.TrimSpace(line) == "" || column > f.column {
+		return true
+	}
+	rest := line[column:]
+	return f.listItems && column == f.column && (rest == "-" || strings.HasPrefix(rest, "- "))
 }
 
 // ensures it implements the interface
@@ -32,128 +124,803 @@ func (p *YAMLPrinter) Print(r io.Reader, w io.Writer) {
 		line := scanner.Text()
 		p.printLineAsYAMLFormat(line, w)
 	}
+	if p.secretData != nil {
+		p.flushSecretData(w)
+	}
+	if p.condition != nil {
+		p.flushCondition(w)
+	}
+	if p.fold != nil {
+		p.flushFold(w)
+	}
 	if err := scanner.Err(); err != nil {
 		slog.Error("Failed to print YAML output.", "error", err)
 	}
 }
 
 func (p *YAMLPrinter) printLineAsYAMLFormat(line string, w io.Writer) {
+	if f := p.fold; f != nil {
+		switch {
+		case f.pendingPEM && findIndent(line) > f.column && isPEM(line):
+			f.pendingPEM = false
+			f.header = f.keyHeader
+			f.lines++
+			return
+		case !f.pendingPEM && f.contains(line):
+			f.lines++
+			return
+		}
+		p.flushFold(w)
+	}
+	if d := p.secretData; d != nil {
+		column := findIndent(line)
+		isBlank := strings.TrimSpace(line) == ""
+		if !isBlank && (column < d.column || isYAMLDocumentMarker(line)) {
+			p.flushSecretData(w)
+		} else {
+			d.lines = append(d.lines, line)
+			if key, value, ok := cutYAMLKey(line[column:]); ok && column == d.column && key == "kind" {
+				p.objectKind = strings.TrimSpace(value)
+				p.flushSecretData(w)
+			}
+			return
+		}
+	}
+	if p.objectKind == "" && p.startsSecretData(line) {
+		p.secretData = &yamlSecretData{column: findIndent(line), lines: []string{line}}
+		return
+	}
+	if p.condition != nil {
+		if strings.TrimSpace(line) == "" || findIndent(line) > p.condition.dashColumn {
+			p.condition.lines = append(p.condition.lines, line)
+			return
+		}
+		p.flushCondition(w)
+	}
+	if p.conditionType == "" && p.startsCondition(line) {
+		p.condition = &yamlCondition{dashColumn: findIndent(line), lines: []string{line}}
+		return
+	}
+
+	var buf strings.Builder
+	p.writeLine(&buf, line)
+	if p.fold != nil {
+		// Started hiding the value, so wait with printing the line
+		// until we know how many lines are hidden.
+		p.fold.header = buf.String()
+		return
+	}
+	buf.WriteByte('\n')
+	io.WriteString(w, buf.String())
+}
+
+// flushFold prints the line that owns the hidden value, with a placeholder.
+func (p *YAMLPrinter) flushFold(w io.Writer) {
+	f := p.fold
+	p.fold = nil
+	if f.pendingPEM || f.hideOnly {
+		io.WriteString(w, f.header+"\n")
+		return
+	}
+	io.WriteString(w, f.header+" "+p.Theme.Data.Folded.Render(foldedYAML(foldedLines(f.lines)))+"\n")
+}
+
+// startFold hides the value of "key:" if it matches [YAMLPrinter.Fold].
+// Returns true if the value has been handled.
+func (p *YAMLPrinter) startFold(buf *strings.Builder, afterKey string, column int) bool {
+	if len(p.Fold) == 0 {
+		return false
+	}
+	path := p.path.String()
+	value, _ := cutYAMLComment(strings.TrimSpace(afterKey))
+	value = strings.TrimSpace(value)
+	isBlockScalar := strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">")
+	switch {
+	case p.Fold.Has(config.FoldManagedFields) && dataPathManagedFields.MatchString(path) && value == "":
+		buf.WriteByte(':')
+		p.fold = &yamlFold{column: column, listItems: true}
+		return true
+	case p.Fold.Has(config.FoldLastApplied) && dataPathLastApplied.MatchString(path) && isBlockScalar:
+		buf.WriteByte(':')
+		p.fold = &yamlFold{column: column}
+		return true
+	case p.Fold.Has(config.FoldLastApplied) && dataPathLastApplied.MatchString(path) && value != "":
+		buf.WriteString(": ")
+		buf.WriteString(p.Theme.Data.Folded.Render(foldedYAML(foldedChars(len(value)))))
+		return true
+	case p.Fold.Has(config.FoldBlobs) && isBlockScalar:
+		// Printed as usual, but the lines are hidden if it's a PEM
+		p.fold = &yamlFold{column: column, pendingPEM: true, keyHeader: buf.String() + ":"}
+		return false
+	case p.Fold.Has(config.FoldBlobs) && isBase64Blob(value) && !p.decodesSecret():
+		buf.WriteString(": ")
+		buf.WriteString(p.Theme.Data.Folded.Render(foldedYAML(foldedChars(len(value)))))
+		return true
+	}
+	return false
+}
+
+func (p *YAMLPrinter) writeLine(buf *strings.Builder, line string) {
 	indentLen := findIndent(line) // can be 0
 	indent := line[:indentLen]
 	trimmedLine := line[indentLen:]
 
-	if p.nextLineSetsIndent {
-		p.multilineStringIndent = indentLen
-		p.nextLineSetsIndent = false
+	if indentLen == 0 && isYAMLDocumentMarker(trimmedLine) {
+		// "---" or "..." resets everything
+		p.reset()
+		buf.WriteString(p.Theme.Data.Comment.Render(trimmedLine[:3]))
+		p.writeValue(buf, trimmedLine[3:], 0, 0)
+		return
 	}
 
-	if p.multilineStringIndent > 0 {
-		if indentLen >= p.multilineStringIndent {
-			fmt.Fprintf(w, "%s%s\n", line[:p.multilineStringIndent], p.Theme.Data.String.Render(line[p.multilineStringIndent:]))
+	if p.blockScalarPending {
+		if strings.TrimSpace(line) == "" {
+			buf.WriteString(line)
 			return
-		} else {
-			p.multilineStringIndent = 0
+		}
+		p.blockScalarPending = false
+		if indentLen > p.blockScalarParent {
+			p.blockScalarIndent = indentLen
 		}
 	}
 
-	if p.inString {
-		// if inString is true, the line must be a part of a string which is broken into several lines
-		fmt.Fprintf(w, "%s%s\n", indent, p.colorizeYAMLStringValue(trimmedLine))
-		p.inString = !p.isStringClosed(trimmedLine)
+	if p.blockScalarIndent > 0 {
+		if strings.TrimSpace(line) == "" {
+			buf.WriteString(line)
+			return
+		}
+		if indentLen >= p.blockScalarIndent {
+			buf.WriteString(line[:p.blockScalarIndent])
+			buf.WriteString(p.Theme.Data.String.Render(line[p.blockScalarIndent:]))
+			return
+		}
+		p.blockScalarIndent = 0
+	}
+
+	if p.quote != 0 {
+		buf.WriteString(indent)
+		end := indexYAMLQuoteEnd(trimmedLine, p.quote)
+		if end == -1 {
+			writeColored(buf, trimmedLine, p.Theme.Data.String)
+			return
+		}
+		writeColored(buf, trimmedLine[:end], p.Theme.Data.String)
+		buf.WriteByte(p.quote)
+		p.quote = 0
+		p.writeTrailing(buf, trimmedLine[end+1:])
 		return
 	}
 
-	if key, val, ok := strings.Cut(trimmedLine, ": "); ok {
-		// key: |
-		//   multiline string
+	if p.inPlain {
+		if indentLen > p.plainIndent && trimmedLine != "" && !strings.HasPrefix(trimmedLine, "#") {
+			buf.WriteString(indent)
+			value, comment := cutYAMLComment(trimmedLine)
+			p.writeScalar(buf, value, p.Theme.Data.String)
+			buf.WriteString(p.Theme.Data.Comment.Render(comment))
+			return
+		}
+		p.inPlain = false
+	}
+
+	if len(p.flow) > 0 {
+		buf.WriteString(indent)
+		p.writeFlow(buf, trimmedLine)
+		return
+	}
 
-		if prefix, after, ok := stringutil.CutPrefixAny(val, "|-", "|+", "|", ">-", ">+", ">"); ok {
-			if after == "" {
-				fmt.Fprintf(w, "%s%s: %s\n", indent, p.colorizeYAMLKey(key, indentLen, 2), prefix)
-				p.nextLineSetsIndent = true
-				return
-			}
-			if num, err := strconv.Atoi(after); err == nil {
-				fmt.Fprintf(w, "%s%s: %s%s\n", indent, p.colorizeYAMLKey(key, indentLen, 2), prefix, p.colorizeYAMLValue(after))
-				p.multilineStringIndent = indentLen + num
-				return
+	p.writeBlockLine(buf, indent, trimmedLine)
+}
+
+// reset clears the parse state at the start of a new document,
+// but keeps the settings, so e.g redaction applies to all documents.
+func (p *YAMLPrinter) reset() {
+	*p = YAMLPrinter{
+		Theme:         p.Theme,
+		Fold:          p.Fold,
+		Redactor:      p.Redactor,
+		DecodeSecrets: p.DecodeSecrets,
+		conditionType: p.conditionType,
+	}
+}
+
+// startsCondition returns true if the line is the start of a new
+// list item inside "status.conditions".
+func (p *YAMLPrinter) startsCondition(line string) bool {
+	column := findIndent(line)
+	rest := line[column:]
+	if rest != "-" && !strings.HasPrefix(rest, "- ") {
+		return false
+	}
+	if p.continuesMultiline(column) {
+		return false
+	}
+	path := slices.Clone(p.path)
+	path.pushYAMLListItem(column)
+	return dataPathConditionItem.MatchString(path.String())
+}
+
+// startsSecretData returns true if the line is the "data:" or "stringData:"
+// key of what might be a Secret, and it should be masked or decoded.
+func (p *YAMLPrinter) startsSecretData(line string) bool {
+	if p.Redactor == nil && !p.DecodeSecrets {
+		return false
+	}
+	column := findIndent(line)
+	if p.continuesMultiline(column) {
+		return false
+	}
+	key, _, ok := cutYAMLKey(line[column:])
+	if !ok {
+		return false
+	}
+	path := slices.Clone(p.path)
+	path.pushYAMLKey(key, column)
+	return dataPathSecretData.MatchString(path.String())
+}
+
+// flushSecretData prints the buffered Secret data, now that the kind is known.
+func (p *YAMLPrinter) flushSecretData(w io.Writer) {
+	data := p.secretData
+	p.secretData = nil
+	if p.objectKind == "" {
+		// Prevents buffering the same lines again
+		p.objectKind = "<unknown>"
+	}
+	for _, line := range data.lines {
+		p.printLineAsYAMLFormat(line, w)
+	}
+}
+
+// continuesMultiline returns true if a line with the given indentation
+// continues a value from the previous lines, such as a block scalar.
+func (p *YAMLPrinter) continuesMultiline(column int) bool {
+	return p.blockScalarPending || (p.blockScalarIndent > 0 && column >= p.blockScalarIndent) ||
+		p.quote != 0 || len(p.flow) > 0 || (p.inPlain && column > p.plainIndent)
+}
+
+// startRedact masks the value of "key:" if the [YAMLPrinter.Redactor] says so.
+// Returns true if the value has been handled.
+func (p *YAMLPrinter) startRedact(buf *strings.Builder, afterKey string, column int) bool {
+	if p.Redactor == nil {
+		return false
+	}
+	path := p.path.String()
+	key := p.path[len(p.path)-1].Key
+	value, _ := cutYAMLComment(strings.TrimSpace(afterKey))
+	value = strings.TrimSpace(value)
+	quote, unquoted, isQuoted := cutYAMLQuotes(value)
+	if dataPathEnvName.MatchString(path) {
+		p.envName = unquoted
+	}
+	if !isRedactable(unquoted) || !p.Redactor.matchDataValue(path, key, p.objectKind, p.envName) {
+		return false
+	}
+	buf.WriteString(": ")
+	if isQuoted {
+		buf.WriteByte(quote)
+		buf.WriteString(p.Redactor.Render())
+		buf.WriteByte(quote)
+	} else {
+		buf.WriteString(p.Redactor.Render())
+	}
+	if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") || (value[0] == '"' || value[0] == '\'') && !isQuoted {
+		// Also hide the rest of the multiline string
+		p.fold = &yamlFold{column: column, hideOnly: true}
+	}
+	return true
+}
+
+// decodesSecret returns true if the value of the current key
+// is decoded by [YAMLPrinter.DecodeSecrets]. Masking by the
+// [YAMLPrinter.Redactor] takes precedence over decoding.
+func (p *YAMLPrinter) decodesSecret() bool {
+	return p.DecodeSecrets && p.Redactor == nil && p.objectKind == "Secret" && dataPathSecretBase64.MatchString(p.path.String())
+}
+
+// writeDecodedSecret writes the decoded value as a comment after a
+// base64 encoded Secret value, if enabled by [YAMLPrinter.DecodeSecrets].
+func (p *YAMLPrinter) writeDecodedSecret(buf *strings.Builder, afterKey string) {
+	if !p.decodesSecret() {
+		return
+	}
+	value, comment := cutYAMLComment(strings.TrimSpace(afterKey))
+	if comment != "" {
+		return
+	}
+	_, unquoted, _ := cutYAMLQuotes(strings.TrimSpace(value))
+	decoded, ok := decodeSecretValue(unquoted)
+	if !ok {
+		return
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(p.Theme.Data.Decoded.Render("# " + decoded))
+}
+
+// flushCondition prints the buffered condition, now that its "type" is known.
+func (p *YAMLPrinter) flushCondition(w io.Writer) {
+	condition := p.condition
+	p.condition = nil
+	p.conditionType = yamlConditionType(condition.lines)
+	if p.conditionType == "" {
+		// Prevents buffering the same item again
+		p.conditionType = "<unknown>"
+	}
+	for _, line := range condition.lines {
+		p.printLineAsYAMLFormat(line, w)
+	}
+	p.conditionType = ""
+}
+
+// yamlConditionType returns the value of the "type" key
+// from the lines of a condition list item.
+func yamlConditionType(lines []string) string {
+	dash := findIndent(lines[0])
+	first := lines[0][dash+1:]
+	column := dash + 1 + findIndent(first)
+	for i, line := range lines {
+		content := first[findIndent(first):]
+		if i > 0 {
+			if findIndent(line) != column {
+				continue
 			}
+			content = line[column:]
+		}
+		key, value, ok := cutYAMLKey(content)
+		if !ok || key != "type" {
+			continue
+		}
+		value, _ = cutYAMLComment(strings.TrimSpace(value))
+		value = strings.TrimSpace(value)
+		if _, unquoted, ok := cutYAMLQuotes(value); ok {
+			return unquoted
 		}
+		return value
+	}
+	return ""
+}
 
-		if quote, afterQuote, ok := stringutil.CutPrefixAny(val, "\"", "'"); ok && !strings.HasSuffix(afterQuote, quote) {
-			// key: "value
-			// (missing final quote)
-			fmt.Fprintf(w, "%s%s: %s%s\n", indent, p.colorizeYAMLKey(key, indentLen, 2), quote, p.Theme.Data.String.Render(afterQuote))
-			p.inString = true
-			return
+// pushYAMLListItem updates the path for a "- " at the given column.
+func (p *dataPath) pushYAMLListItem(column int) {
+	path := *p
+	for len(path) > 0 && path[len(path)-1].Column > column {
+		path = path[:len(path)-1]
+	}
+	if len(path) > 0 && path[len(path)-1].Column == column && path[len(path)-1].Key == dataPathListItem {
+		path = path[:len(path)-1]
+	}
+	*p = append(path, dataSegment{Key: dataPathListItem, Column: column})
+}
+
+// pushYAMLKey updates the path for a "key:" at the given column.
+func (p *dataPath) pushYAMLKey(key string, column int) {
+	path := *p
+	for len(path) > 0 && path[len(path)-1].Column >= column {
+		path = path[:len(path)-1]
+	}
+	key = strings.TrimRight(key, " \t")
+	if _, unquoted, ok := cutYAMLQuotes(key); ok {
+		key = unquoted
+	}
+	*p = append(path, dataSegment{Key: key, Column: column})
+}
+
+// writeBlockLine writes a line in the regular indentation-based YAML syntax,
+// e.g "key: value" or "- item".
+func (p *YAMLPrinter) writeBlockLine(buf *strings.Builder, indent, rest string) {
+	buf.WriteString(indent)
+	if strings.HasPrefix(rest, "#") || (indent == "" && strings.HasPrefix(rest, "%")) {
+		// # comment
+		// %YAML 1.2
+		buf.WriteString(p.Theme.Data.Comment.Render(rest))
+		return
+	}
+
+	column := len(indent)
+	parentColumn := column
+	for rest == "-" || strings.HasPrefix(rest, "- ") {
+		// - item
+		// - - nested item
+		parentColumn = column
+		p.path.pushYAMLListItem(column)
+		p.envName = ""
+		if len(p.path) == 2 && p.path[0].Key == "items" {
+			// new object in a List
+			p.objectKind = ""
 		}
+		n := 1 + findIndent(rest[1:])
+		buf.WriteString(rest[:n])
+		column += n
+		rest = rest[n:]
+	}
+
+	properties, rest := cutYAMLProperties(rest)
+	if properties != "" {
+		p.writeProperties(buf, properties)
+		column += len(properties)
+	}
 
+	if key, afterKey, ok := cutYAMLKey(rest); ok {
 		// key: value
-		fmt.Fprintf(w, "%s%s: %s\n", indent, p.colorizeYAMLKey(key, indentLen, 2), p.colorizeYAMLValue(val))
+		p.path.pushYAMLKey(key, column)
+		p.writeKey(buf, key, ColorDataKey(column, 2, p.Theme.Data.Key))
+		if dataPathKind.MatchString(p.path.String()) {
+			p.objectKind = strings.TrimSpace(afterKey)
+		}
+		if p.startFold(buf, afterKey, column) || p.startRedact(buf, afterKey, column) {
+			return
+		}
+		buf.WriteByte(':')
+		p.writeValue(buf, afterKey, column, column/2+1)
+		p.writeDecodedSecret(buf, afterKey)
 		return
 	}
 
-	// when coming here, the line is just a "key:" or an element of an array
-	if strings.HasSuffix(trimmedLine, ":") {
-		// key:
-		fmt.Fprintf(w, "%s%s\n", indent, p.colorizeYAMLKey(trimmedLine, indentLen, 2))
+	p.writeValue(buf, rest, parentColumn, column/2)
+}
+
+// writeValue writes the value after "key:" or "- ".
+// The parentColumn is the indentation of the node that owns the value,
+// and flowDepth is the key depth to use if the value is a flow collection.
+func (p *YAMLPrinter) writeValue(buf *strings.Builder, s string, parentColumn, flowDepth int) {
+	spaces := findIndent(s)
+	buf.WriteString(s[:spaces])
+	s = s[spaces:]
+
+	properties, s := cutYAMLProperties(s)
+	p.writeProperties(buf, properties)
+
+	if s == "" {
 		return
 	}
 
-	fmt.Fprintf(w, "%s%s\n", indent, p.colorizeYAMLValue(trimmedLine))
+	switch s[0] {
+	case '#':
+		buf.WriteString(p.Theme.Data.Comment.Render(s))
+	case '*':
+		// *alias
+		alias, rest := cutYAMLToken(s)
+		buf.WriteString(p.Theme.Data.Anchor.Render(alias))
+		p.writeTrailing(buf, rest)
+	case '|', '>':
+		if !p.writeBlockScalarHeader(buf, s, parentColumn) {
+			p.writePlain(buf, s, parentColumn)
+		}
+	case '"', '\'':
+		p.writeQuoted(buf, s)
+	case '{', '[':
+		p.flowBaseDepth = flowDepth
+		p.writeFlow(buf, s)
+	default:
+		p.writePlain(buf, s, parentColumn)
+	}
 }
 
-func (p *YAMLPrinter) colorizeYAMLKey(key string, indentCnt, basicWidth int) string {
-	hasColon := strings.HasSuffix(key, ":")
-	hasLeadingDash := strings.HasPrefix(key, "- ")
-	key = strings.TrimSuffix(key, ":")
-	key = strings.TrimPrefix(key, "- ")
+func (p *YAMLPrinter) writePlain(buf *strings.Builder, s string, parentColumn int) {
+	value, comment := cutYAMLComment(s)
+	p.writeScalar(buf, value, p.valueColor(strings.TrimRight(value, " \t")))
+	buf.WriteString(p.Theme.Data.Comment.Render(comment))
+	p.inPlain = true
+	p.plainIndent = parentColumn
+}
 
-	format := "%s"
-	if hasColon {
-		format += ":"
+// writeBlockScalarHeader writes the "|" or ">" that starts a multiline
+// string, including its chomping and indentation indicators, e.g "|-" or ">2".
+// Returns false if it's not a valid block scalar header.
+func (p *YAMLPrinter) writeBlockScalarHeader(buf *strings.Builder, s string, parentColumn int) bool {
+	header, rest := cutYAMLToken(s)
+	if len(header) > 3 {
+		return false
+	}
+	indentIndicator := 0
+	for _, r := range header[1:] {
+		switch {
+		case r == '-' || r == '+':
+		case r >= '1' && r <= '9' && indentIndicator == 0:
+			indentIndicator = int(r - '0')
+		default:
+			return false
+		}
+	}
+	if rest = strings.TrimLeft(rest, " \t"); rest != "" && rest[0] != '#' {
+		return false
 	}
 
-	if hasLeadingDash {
-		format = "- " + format
-		indentCnt += 2
+	for i := range header {
+		if digit := header[i : i+1]; i > 0 && indentIndicator > 0 && digit == strconv.Itoa(indentIndicator) {
+			buf.WriteString(ColorDataValue(digit, p.Theme).Render(digit))
+		} else {
+			buf.WriteByte(header[i])
+		}
 	}
+	p.writeTrailing(buf, s[len(header):])
 
-	return fmt.Sprintf(format, ColorDataKey(indentCnt, basicWidth, p.Theme.Data.Key).Render(key))
+	if indentIndicator > 0 {
+		p.blockScalarIndent = parentColumn + indentIndicator
+	} else {
+		p.blockScalarPending = true
+		p.blockScalarParent = parentColumn
+	}
+	return true
 }
 
-func (p *YAMLPrinter) colorizeYAMLValue(value string) string {
-	switch value {
-	case "{}", "- {}", "[]", "- []":
-		return value
+func (p *YAMLPrinter) writeQuoted(buf *strings.Builder, s string) {
+	quote := s[0]
+	end := indexYAMLQuoteEnd(s[1:], quote) + 1
+	if end == 0 {
+		// key: "value
+		// (missing final quote, so it continues on the next line)
+		buf.WriteByte(quote)
+		writeColored(buf, s[1:], p.Theme.Data.String)
+		p.quote = quote
+		return
 	}
 
-	value, hasLeadingDash := strings.CutPrefix(value, "- ")
-	quote, unquotedValue, isDoubleQuoted := stringutil.CutSurroundingAny(value, "\"'")
-	color := ColorDataValue(value, p.Theme)
+	rest := s[end+1:]
+	if trimmed := strings.TrimSpace(rest); trimmed != "" && trimmed[0] != '#' &&
+		len(s) > 1 && strings.HasSuffix(s, string(quote)) {
+		// key: "foo"bar"
+		// Not valid YAML, but let's treat the whole thing as a string.
+		end = len(s) - 1
+		rest = ""
+	}
+	p.writeScalar(buf, s[:end+1], p.valueColor(s[:end+1]))
+	p.writeTrailing(buf, rest)
+}
 
-	switch {
-	case hasLeadingDash && isDoubleQuoted:
-		return fmt.Sprintf(`- %c%s%c`, quote, color.Render(unquotedValue), quote)
-	case hasLeadingDash:
-		return fmt.Sprintf(`- %s`, color.Render(value))
-	case isDoubleQuoted:
-		return fmt.Sprintf(`%c%s%c`, quote, color.Render(unquotedValue), quote)
-	default:
-		return color.Render(value)
+// valueColor returns the color for a value in block style,
+// taking the path to the value into account.
+func (p *YAMLPrinter) valueColor(value string) color.Color {
+	unquoted := value
+	if _, s, ok := cutYAMLQuotes(value); ok {
+		unquoted = s
+	}
+	if c, ok := colorKubernetesDataValue(p.path.String(), unquoted, p.conditionType, p.Theme); ok {
+		return c
 	}
+	return ColorDataValue(value, p.Theme)
 }
 
-func (p *YAMLPrinter) colorizeYAMLStringValue(value string) string {
-	if before, ok := strings.CutSuffix(value, "\""); ok {
-		return fmt.Sprintf(`%s"`, p.Theme.Data.String.Render(before))
+// writeFlow writes a flow collection, e.g "{a: 1, b: [2, 3]}",
+// which may span multiple lines.
+func (p *YAMLPrinter) writeFlow(buf *strings.Builder, s string) {
+	for len(s) > 0 {
+		c := s[0]
+		switch {
+		case c == '{' || c == '[':
+			p.flow = append(p.flow, c)
+			p.flowExpectKey = c == '{'
+		case c == '}' || c == ']':
+			if len(p.flow) > 0 {
+				p.flow = p.flow[:len(p.flow)-1]
+			}
+			p.flowExpectKey = false
+			if len(p.flow) == 0 {
+				buf.WriteByte(c)
+				p.writeTrailing(buf, s[1:])
+				return
+			}
+		case c == ',':
+			p.flowExpectKey = p.inFlowMap()
+		case c == ':':
+			p.flowExpectKey = false
+		case c == ' ' || c == '\t':
+		case c == '#' && (buf.Len() == 0 || strings.HasSuffix(buf.String(), " ")):
+			buf.WriteString(p.Theme.Data.Comment.Render(s))
+			return
+		case c == '&' || c == '*' || c == '!':
+			token := s[:indexYAMLFlowTokenEnd(s)]
+			if c == '!' {
+				buf.WriteString(p.Theme.Data.Tag.Render(token))
+			} else {
+				buf.WriteString(p.Theme.Data.Anchor.Render(token))
+			}
+			s = s[len(token):]
+			continue
+		case c == '"' || c == '\'':
+			end := indexYAMLQuoteEnd(s[1:], c) + 1
+			if end == 0 {
+				buf.WriteByte(c)
+				writeColored(buf, s[1:], p.Theme.Data.String)
+				return
+			}
+			p.writeFlowScalar(buf, s[:end+1], s[end+1:])
+			s = s[end+1:]
+			continue
+		default:
+			token := s[:indexYAMLFlowTokenEnd(s)]
+			value := strings.TrimRight(token, " \t")
+			p.writeFlowScalar(buf, value, s[len(value):])
+			s = s[len(value):]
+			continue
+		}
+		buf.WriteByte(c)
+		s = s[1:]
 	}
-	if before, ok := strings.CutSuffix(value, "'"); ok {
-		return fmt.Sprintf(`%s'`, p.Theme.Data.String.Render(before))
+}
+
+func (p *YAMLPrinter) writeFlowScalar(buf *strings.Builder, token, after string) {
+	isKey := p.inFlowMap() && p.flowExpectKey ||
+		strings.HasPrefix(strings.TrimLeft(after, " \t"), ":")
+	if isKey {
+		depth := p.flowBaseDepth + len(p.flow) - 1
+		p.writeKey(buf, token, ColorDataKey(depth, 1, p.Theme.Data.Key))
+		return
+	}
+	p.writeScalar(buf, token, ColorDataValue(token, p.Theme))
+}
+
+func (p *YAMLPrinter) inFlowMap() bool {
+	return len(p.flow) > 0 && p.flow[len(p.flow)-1] == '{'
+}
+
+func (p *YAMLPrinter) writeKey(buf *strings.Builder, key string, c color.Color) {
+	trimmed := strings.TrimRight(key, " \t")
+	p.writeScalar(buf, trimmed, c)
+	buf.WriteString(key[len(trimmed):])
+}
+
+// writeScalar writes a value with the given color,
+// where quotes and trailing spaces are left uncolored.
+func (p *YAMLPrinter) writeScalar(buf *strings.Builder, s string, c color.Color) {
+	trimmed := strings.TrimRight(s, " \t")
+	if quote, unquoted, ok := cutYAMLQuotes(trimmed); ok {
+		buf.WriteByte(quote)
+		writeColored(buf, unquoted, c)
+		buf.WriteByte(quote)
+	} else {
+		writeColored(buf, trimmed, c)
+	}
+	buf.WriteString(s[len(trimmed):])
+}
+
+func (p *YAMLPrinter) writeProperties(buf *strings.Builder, properties string) {
+	for properties != "" {
+		token, rest := cutYAMLToken(properties)
+		if strings.HasPrefix(token, "!") {
+			buf.WriteString(p.Theme.Data.Tag.Render(token))
+		} else {
+			buf.WriteString(p.Theme.Data.Anchor.Render(token))
+		}
+		properties = strings.TrimLeft(rest, " \t")
+		buf.WriteString(rest[:len(rest)-len(properties)])
+	}
+}
+
+// writeTrailing writes what comes after a value, which can only be
+// whitespace and a comment in valid YAML.
+func (p *YAMLPrinter) writeTrailing(buf *strings.Builder, s string) {
+	trimmed := strings.TrimLeft(s, " \t")
+	buf.WriteString(s[:len(s)-len(trimmed)])
+	if strings.HasPrefix(trimmed, "#") {
+		buf.WriteString(p.Theme.Data.Comment.Render(trimmed))
+	} else {
+		buf.WriteString(trimmed)
+	}
+}
+
+func writeColored(buf *strings.Builder, s string, c color.Color) {
+	if s != "" {
+		buf.WriteString(c.Render(s))
+	}
+}
+
+func isYAMLDocumentMarker(line string) bool {
+	if !strings.HasPrefix(line, "---") && !strings.HasPrefix(line, "...") {
+		return false
 	}
-	return p.Theme.Data.String.Render(value)
+	return len(line) == 3 || line[3] == ' ' || line[3] == '\t'
 }
 
-func (p *YAMLPrinter) isStringClosed(line string) bool {
-	return strings.HasSuffix(line, "'") || strings.HasSuffix(line, `"`)
+// cutYAMLKey cuts the key from "key: value" or "key:", where
+// the key may be quoted and contain ": ", e.g `"foo: bar": value`.
+func cutYAMLKey(s string) (key, after string, ok bool) {
+	if s == "" {
+		return "", "", false
+	}
+	start := 0
+	switch s[0] {
+	case '"', '\'':
+		start = indexYAMLQuoteEnd(s[1:], s[0]) + 2
+		if start == 1 {
+			return "", "", false
+		}
+	case '{', '[', '#', '|', '>', '*', '&', '!', '%', '@', '`':
+		return "", "", false
+	}
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case ':':
+			if i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t' {
+				return s[:i], s[i+1:], true
+			}
+		case '#':
+			if i > 0 && (s[i-1] == ' ' || s[i-1] == '\t') {
+				return "", "", false
+			}
+		}
+		if start > 0 && s[i] != ' ' && s[i] != '\t' && s[i] != ':' {
+			// Quoted strings must be directly followed by the colon
+			return "", "", false
+		}
+	}
+	return "", "", false
+}
+
+// cutYAMLProperties cuts any anchors (&anchor) and tags (!!str)
+// from the start of a node.
+func cutYAMLProperties(s string) (properties, rest string) {
+	rest = s
+	for strings.HasPrefix(rest, "&") || strings.HasPrefix(rest, "!") {
+		token, after := cutYAMLToken(rest)
+		rest = strings.TrimLeft(after, " \t")
+		if token == "" {
+			break
+		}
+	}
+	return s[:len(s)-len(rest)], rest
+}
+
+// cutYAMLToken cuts at the first whitespace.
+func cutYAMLToken(s string) (token, rest string) {
+	if i := strings.IndexAny(s, " \t"); i != -1 {
+		return s[:i], s[i:]
+	}
+	return s, ""
+}
+
+// cutYAMLComment cuts a trailing " # comment" from a plain string.
+func cutYAMLComment(s string) (value, comment string) {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') {
+			return s[:i], s[i:]
+		}
+	}
+	return s, ""
+}
+
+func cutYAMLQuotes(s string) (quote byte, unquoted string, ok bool) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') || s[len(s)-1] != s[0] {
+		return 0, s, false
+	}
+	return s[0], s[1 : len(s)-1], true
+}
+
+// indexYAMLQuoteEnd returns the index of the closing quote,
+// or -1 if the string is not closed. The opening quote must not be included.
+func indexYAMLQuoteEnd(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++ // skip escaped character
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++ // skip escaped single quote: ''
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// indexYAMLFlowTokenEnd returns the end of a plain string,
+// anchor, alias, or tag inside a flow collection.
+func indexYAMLFlowTokenEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case ',', '[', ']', '{', '}':
+			return i
+		case ':':
+			if i+1 == len(s) || strings.IndexByte(" \t,[]{}", s[i+1]) != -1 {
+				return i
+			}
+		case '#':
+			if i > 0 && (s[i-1] == ' ' || s[i-1] == '\t') {
+				return i
+			}
+		case ' ', '\t':
+			if s[0] == '&' || s[0] == '*' || s[0] == '!' {
+				return i
+			}
+		}
+	}
+	return len(s)
 }
@@ -3,10 +3,13 @@ package printer
 import (
 	"bytes"
 	"errors"
+	"strings"
 	"testing"
 
+	"github.com/kubecolor/kubecolor/config"
 	"github.com/kubecolor/kubecolor/config/testconfig"
 	"github.com/kubecolor/kubecolor/testutil"
+	"gopkg.in/yaml.v3"
 )
 
 func TestYAMLPrinter_fail(t *testing.T) {
@@ -20,3 +23,41 @@ func TestYAMLPrinter_fail(t *testing.T) {
 	testutil.Equal(t, "", outBuf.String(), "output")
 	testutil.Equal(t, "level=ERROR msg=\"Failed to print YAML output.\" error=test\n", logBuf.String(), "logs")
 }
+
+func TestYAMLPrinter_foldRoundTrip(t *testing.T) {
+	input := testutil.NewHereDoc(`
+		apiVersion: v1
+		kind: Secret
+		metadata:
+		  annotations:
+		    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1"}'
+		  managedFields:
+		  - manager: kubectl
+		    operation: Update
+		  name: foo
+		data:
+		  blob: ` + strings.Repeat("QUJD", 30) + `
+		  short: c2hvcnQ=
+		`)
+
+	var outBuf bytes.Buffer
+	printer := YAMLPrinter{Theme: testconfig.NullTheme, Fold: config.Fold(config.AllFoldTargets)}
+	printer.Print(strings.NewReader(input), &outBuf)
+
+	var got map[string]any
+	testutil.MustNoError(t, yaml.Unmarshal(outBuf.Bytes(), &got))
+	want := map[string]any{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]any{
+			"annotations":   map[string]any{"kubectl.kubernetes.io/last-applied-configuration": nil},
+			"managedFields": nil,
+			"name":          "foo",
+		},
+		"data": map[string]any{
+			"blob":  nil,
+			"short": "c2hvcnQ=",
+		},
+	}
+	testutil.Equal(t, want, got)
+}
@@ -7,9 +7,11 @@ import (
 	"io"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/kubecolor/kubecolor/internal/bytesutil"
 	"github.com/kubecolor/kubecolor/internal/ctxscanner"
+	"github.com/kubecolor/kubecolor/internal/stringutil"
 )
 
 var DefaultBufferTimeout = 50 * time.Millisecond
@@ -38,8 +40,13 @@ func NewCell(full string) Cell {
 type Scanner struct {
 	BufferTimeout time.Duration
 
-	lineScanner     *ctxscanner.Scanner
+	lineScanner *ctxscanner.Scanner
+	// headerIndices are the display columns where each table column starts,
+	// which differs from the byte offsets when the table contains
+	// multi-byte or wide runes, e.g "日本語".
 	headerIndices   []int
+	cellOffsets     []int
+	lineMask        []byte
 	currentCells    []Cell
 	currentLine     string
 	leadingSpaces   string
@@ -99,24 +106,24 @@ func (s *Scanner) Scan() bool {
 		return true
 	}
 
-	if len(s.headerIndices) > 0 {
-		s.leadingSpaces = s.currentLine[:s.headerIndices[0]]
+	s.cellOffsets = calcCellOffsets(s.cellOffsets[:0], s.currentLine, s.headerIndices)
+	if len(s.cellOffsets) > 0 {
+		s.leadingSpaces = s.currentLine[:s.cellOffsets[0]]
 	}
 
-	for i, columnIndex := range s.headerIndices {
-		if columnIndex >= len(s.currentLine) {
+	for i, offset := range s.cellOffsets {
+		if offset >= len(s.currentLine) {
 			// empty cell at end of line
 			s.currentCells = append(s.currentCells, emptyCell)
 			continue
 		}
 		var str string
-		if i+1 < len(s.headerIndices) {
+		if i+1 < len(s.cellOffsets) {
 			// there's more lines
-			nextColumnIndex := s.headerIndices[i+1]
-			str = s.currentLine[columnIndex:min(nextColumnIndex, len(s.currentLine))]
+			str = s.currentLine[offset:s.cellOffsets[i+1]]
 		} else {
 			// last column
-			str = s.currentLine[columnIndex:]
+			str = s.currentLine[offset:]
 		}
 		s.currentCells = append(s.currentCells, NewCell(str))
 	}
@@ -174,7 +181,8 @@ func (s *Scanner) bufferTableLines() bool {
 			return len(s.bufferedLines) > 0
 		}
 
-		combinedLines = bytewiseAndNonSpace(combinedLines, b)
+		s.lineMask = displayMask(s.lineMask[:0], b)
+		combinedLines = bytewiseAndNonSpace(combinedLines, s.lineMask)
 		newHeaderIndices := calcHeaderIndices(combinedLines)
 
 		if isProbablyNewTable(s.headerIndices, combinedLines) {
@@ -191,6 +199,50 @@ func (s *Scanner) bufferTableLines() bool {
 	}
 }
 
+// calcCellOffsets appends the byte offsets in the line of where each cell starts,
+// based on the display columns of the header indices.
+// A wide rune that spans over the start of a column belongs to the previous cell,
+// so the cells are never split in the middle of a rune.
+func calcCellOffsets(offsets []int, line string, headerIndices []int) []int {
+	var column int
+	var offset int
+	for _, headerIndex := range headerIndices {
+		for offset < len(line) && column < headerIndex {
+			r, size := utf8.DecodeRuneInString(line[offset:])
+			column += stringutil.RuneWidth(r)
+			offset += size
+		}
+		// zero-width runes, e.g combining accents, belong to the previous rune
+		for offset < len(line) {
+			r, size := utf8.DecodeRuneInString(line[offset:])
+			if stringutil.RuneWidth(r) != 0 {
+				break
+			}
+			offset += size
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// displayMask appends one byte per display column of the line, so a wide
+// rune such as "日" takes up two bytes and a combining accent takes up none.
+// Spaces and tabs are kept as-is, while other runes are replaced by non-space bytes.
+func displayMask(mask, line []byte) []byte {
+	for len(line) > 0 {
+		r, size := utf8.DecodeRune(line)
+		if r < utf8.RuneSelf {
+			mask = append(mask, line[0])
+		} else {
+			for range stringutil.RuneWidth(r) {
+				mask = append(mask, '*')
+			}
+		}
+		line = line[size:]
+	}
+	return mask
+}
+
 func isProbablyNewTable(headerIndices []int, line []byte) bool {
 	if len(headerIndices) == 0 {
 		return false
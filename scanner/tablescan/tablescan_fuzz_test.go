@@ -0,0 +1,40 @@
+package tablescan
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/kubecolor/kubecolor/internal/stringutil"
+)
+
+func FuzzScanner(f *testing.F) {
+	f.Add("NAME    READY   STATUS\npod-a   1/1     Running\n")
+	f.Add("NAME    LABEL   AGE\npod-a   日本語  1d\npod-b   café    2d\n")
+	f.Add("A  B\na 日 x\n😀  café\n")
+	f.Fuzz(func(t *testing.T, input string) {
+		// treat CR as LF
+		input = strings.ReplaceAll(input, "\r", "\n")
+		// ignore if string is >2kB
+		input = stringutil.Truncate(input, 2048)
+
+		s := NewScanner(strings.NewReader(input))
+		for s.Scan() {
+			cells := s.Cells()
+			if len(cells) == 0 {
+				continue
+			}
+			var joined strings.Builder
+			joined.WriteString(s.LeadingSpaces())
+			for _, cell := range cells {
+				if utf8.ValidString(s.Text()) && !utf8.ValidString(cell.Full) {
+					t.Fatalf("cell split a rune: %q in line %q", cell.Full, s.Text())
+				}
+				joined.WriteString(cell.Full)
+			}
+			if joined.String() != s.Text() {
+				t.Fatalf("cells don't add up to the line:\nwant: %q\ngot:  %q", s.Text(), joined.String())
+			}
+		}
+	})
+}
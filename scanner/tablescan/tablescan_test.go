@@ -141,6 +141,25 @@ func TestScanner_noHeaderAndEmptyCells(t *testing.T) {
 	}
 }
 
+func TestScanner_wideRunes(t *testing.T) {
+	const input = "" +
+		"NAME    LABEL      DESCRIPTION   AGE\n" +
+		"pod-a   日本語     最初の        250d\n" +
+		"pod-b   café       emoji 😀 ok   13d\n" +
+		"pod-c   cafe\u0301       ascii         1d\n"
+
+	s := NewScanner(strings.NewReader(input))
+
+	mustScanCells(t, s, "NAME", "LABEL", "DESCRIPTION", "AGE")
+	mustScanCells(t, s, "pod-a", "日本語", "最初の", "250d")
+	mustScanCells(t, s, "pod-b", "café", "emoji 😀 ok", "13d")
+	mustScanCells(t, s, "pod-c", "cafe\u0301", "ascii", "1d")
+
+	if s.Scan() {
+		t.Fatalf("Expected no more scans, but got: %q", s.Bytes())
+	}
+}
+
 func mustScanCells(t *testing.T, s *Scanner, cells ...string) {
 	t.Helper()
 	if !s.Scan() {